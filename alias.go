@@ -0,0 +1,134 @@
+package clic
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// AliasError describes a user-defined alias that cannot be expanded. Chain
+// holds the names expanded in order, ending with the name that could not be
+// used (i.e. a repeated alias, or a name that is not a subcommand).
+type AliasError struct {
+	child error
+	Name  string
+	Chain []string
+}
+
+// Error implements the error interface.
+func (e *AliasError) Error() string {
+	if errors.Is(e.child, ErrAliasRecursive) {
+		return fmt.Sprintf("%v: %s", e.child, strings.Join(e.Chain, " -> "))
+	}
+	return fmt.Sprintf("%v: %q expands to %q", e.child, e.Name, e.Chain[len(e.Chain)-1])
+}
+
+// Unwrap implements the [errors] Unwrap anonymous interface.
+func (e *AliasError) Unwrap() error {
+	return e.child
+}
+
+// Is implements the [errors] Is anonymous interface.
+func (e *AliasError) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// DefineAlias adds a user-defined alias that expands to the provided args when
+// used in place of a subcommand name of the Clic instance. For example, "co"
+// might expand to "checkout --force". The expansion is split using the same
+// rules as [Clic.ParseLine], and must begin with a subcommand name or another
+// user-defined alias. Expansion happens during Parse, before the subcommand is
+// resolved. Recursive expansion is reported as [ErrAliasRecursive], and an
+// expansion that does not begin with a subcommand (or plugin) name is reported
+// as [ErrAliasUnresolved] (both wrapped by [AliasError]). Use [Clic.Validate] to check aliases in advance.
+func (c *Clic) DefineAlias(name, expansion string) error {
+	if name == "" || strings.HasPrefix(name, "-") {
+		return fmt.Errorf("%w: name %q", ErrAliasInvalid, name)
+	}
+
+	if sub := c.lookupSub(name); sub != nil {
+		return fmt.Errorf("%w: %q shadows %q", ErrAliasShadowsCommand, name, sub.FlagSet.Name())
+	}
+
+	args, err := splitLine(expansion)
+	if err != nil {
+		return fmt.Errorf("%w: %q: %w", ErrAliasInvalid, name, err)
+	}
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("%w: %q: expansion must begin with a command", ErrAliasInvalid, name)
+	}
+
+	if c.argAlias == nil {
+		c.argAlias = make(map[string][]string)
+	}
+	c.argAlias[name] = args
+
+	return nil
+}
+
+// LoadAliases reads user-defined aliases (see [Clic.DefineAlias]) from r. Each
+// line holds one definition in the form "name = expansion". Blank lines and
+// lines beginning with "#" are ignored.
+func (c *Clic) LoadAliases(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, expansion, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf("line %d: %w: missing \"=\"", n, ErrAliasInvalid)
+		}
+
+		if err := c.DefineAlias(strings.TrimSpace(name), expansion); err != nil {
+			return fmt.Errorf("line %d: %w", n, err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// expandAlias returns the fully expanded args for the named user-defined alias.
+func (c *Clic) expandAlias(name string) ([]string, error) {
+	var seen []string
+	args := []string{name}
+
+	for c.lookupSub(args[0]) == nil {
+		expansion, ok := c.argAlias[args[0]]
+		if !ok {
+			break
+		}
+
+		if slices.Contains(seen, args[0]) {
+			return nil, &AliasError{ErrAliasRecursive, name, append(seen, args[0])}
+		}
+		seen = append(seen, args[0])
+
+		args = append(slices.Clone(expansion), args[1:]...)
+	}
+
+	if c.lookupSub(args[0]) == nil && (c.Plugins == nil || c.Plugins.lookup(c, args[0]) == "") {
+		return nil, &AliasError{ErrAliasUnresolved, name, append(seen, args[0])}
+	}
+
+	return args, nil
+}
+
+// isSubCmdName reports whether name resolves to a subcommand, user-defined
+// alias, or plugin.
+func (c *Clic) isSubCmdName(name string) bool {
+	if c.lookupSub(name) != nil {
+		return true
+	}
+	if _, ok := c.argAlias[name]; ok {
+		return true
+	}
+	return c.Plugins != nil && c.Plugins.lookup(c, name) != ""
+}
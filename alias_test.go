@@ -0,0 +1,74 @@
+package clic
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestClicAliases(t *testing.T) {
+	var (
+		force  bool
+		branch string
+	)
+
+	checkout := New(nil, "checkout|ch")
+	checkout.Flag(&force, "force|f", "")
+	checkout.Operand(&branch, false, "branch", "")
+
+	root := New(nil, "myapp", checkout)
+
+	defs := strings.NewReader(`
+# shortcuts
+co  = checkout --force
+com = co main
+`)
+	if err := root.LoadAliases(defs); err != nil {
+		t.Fatal(err)
+	}
+
+	resolved, err := root.Parse([]string{"com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := []any{resolved, force, branch}; !reflect.DeepEqual(got, []any{checkout, true, "main"}) {
+		t.Fatalf("got: %v, want: checkout true main", got)
+	}
+
+	tt := []struct {
+		name, expansion string
+		want            error
+	}{
+		{"ch", "checkout", ErrAliasShadowsCommand},
+		{"checkout", "ch", ErrAliasShadowsCommand},
+		{"-x", "checkout", ErrAliasInvalid},
+		{"fl", "--force checkout", ErrAliasInvalid},
+		{"q", `checkout "main`, ErrLineUnterminated},
+		{"ok", "checkout", nil},
+	}
+	for _, tc := range tt {
+		if err := root.DefineAlias(tc.name, tc.expansion); !errors.Is(err, tc.want) {
+			t.Errorf("%s: got: %v, want: %v", tc.name, err, tc.want)
+		}
+	}
+
+	if err := root.LoadAliases(strings.NewReader("a = b\nbad")); err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+		t.Fatalf("load: got: %v, want: line 2 error", err)
+	}
+
+	_ = root.DefineAlias("a", "b")
+	_ = root.DefineAlias("b", "a")
+	if _, err := root.Parse([]string{"a"}); !errors.Is(err, CauseParseAliasRecursive) {
+		t.Fatalf("recursion: got: %v, want: %v", err, CauseParseAliasRecursive)
+	} else if got, want := UserFriendlyError(err).Error(), `Alias "a" expands to itself (a -> b -> a)`; got != want {
+		t.Fatalf("recursion message: got: %q, want: %q", got, want)
+	}
+
+	_ = root.DefineAlias("st", "status")
+	if _, err := root.Parse([]string{"st"}); !errors.Is(err, CauseParseAliasUnresolved) {
+		t.Fatalf("unresolved: got: %v, want: %v", err, CauseParseAliasUnresolved)
+	} else if got, want := UserFriendlyError(err).Error(), `Alias "st" expands to unknown command "status"`; got != want {
+		t.Fatalf("unresolved message: got: %q, want: %q", got, want)
+	}
+}
@@ -0,0 +1,35 @@
+package clic
+
+// Blueprint describes a command tree using a constructor function so that each
+// call to Parse can work on a fresh tree with its own bound values. Unlike a
+// single Clic tree, a Blueprint can be parsed and handled from multiple
+// goroutines at once, as long as build does not share state between the trees
+// it returns (i.e. flag and operand values should be declared within build and
+// captured by the handlers that use them).
+type Blueprint struct {
+	build func() *Clic
+}
+
+// NewBlueprint returns an instance of Blueprint that uses build to construct a
+// command tree for each call to Parse.
+func NewBlueprint(build func() *Clic) *Blueprint {
+	return &Blueprint{build: build}
+}
+
+// Parse builds a fresh command tree and resolves arguments to the relevant
+// *Clic instance within it. See [Clic.Parse].
+func (b *Blueprint) Parse(args []string) (*Clic, error) {
+	return b.build().Parse(args)
+}
+
+// ParseLine builds a fresh command tree and resolves line to the relevant
+// *Clic instance within it. See [Clic.ParseLine].
+func (b *Blueprint) ParseLine(line string) (*Clic, error) {
+	return b.build().ParseLine(line)
+}
+
+// Root returns a fresh command tree. It can be used for purposes that do not
+// involve parsing such as generating usage or a [Spec].
+func (b *Blueprint) Root() *Clic {
+	return b.build()
+}
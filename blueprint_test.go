@@ -0,0 +1,62 @@
+package clic
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestBlueprintConcurrent(t *testing.T) {
+	bp := NewBlueprint(func() *Clic {
+		var (
+			info string
+			num  int
+			out  = new(string)
+		)
+
+		leaf := NewFromFunc(func(ctx context.Context) error {
+			*out = fmt.Sprintf("%s:%d", info, num)
+			return nil
+		}, "leaf")
+		leaf.Flag(&num, "num|n", "")
+		leaf.Meta["out"] = out
+
+		root := NewFromFunc(nil, "myapp", leaf)
+		root.Flag(&info, "info|i", "")
+		return root
+	})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			line := fmt.Sprintf("--info=tenant%d leaf -n %d", i, i)
+			cmd, err := bp.ParseLine(line)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if err := cmd.Handle(context.Background()); err != nil {
+				errs <- err
+				return
+			}
+
+			got := *cmd.Meta["out"].(*string)
+			if want := fmt.Sprintf("tenant%d:%d", i, i); got != want {
+				errs <- fmt.Errorf("got: %s, want: %s", got, want)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
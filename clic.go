@@ -2,13 +2,14 @@ package clic
 
 import (
 	"context"
-	"errors"
+	"io"
 	"slices"
 	"strings"
 
 	"github.com/daved/clic/cerrs"
 	"github.com/daved/flagset"
 	"github.com/daved/operandset"
+	"github.com/daved/vtypes"
 )
 
 // Handler describes types that can be used to handle CLI command requests.
@@ -35,9 +36,21 @@ type Clic struct {
 	Category       string
 	SubCmdCatsSort []string
 	Meta           map[string]any
+	Catalog        Catalog   // user-facing text; nil uses default (English)
+	Examples       []Example // see VerifyExamples
 
 	// Additional Configuration
-	SubRequired bool
+	SubRequired       bool
+	Interspersed      bool     // allow flags after operands; applies to subcommands
+	Passthrough       bool     // capture args after flags verbatim; see PassthroughArgs
+	ResetOnParse      bool     // restore defaults before each Parse; see Reset
+	Prompter          Prompter // prompts for missing required operands
+	Secrets           []string // names of operands prompted for with masked input
+	Plugins           *Plugins // dispatch unmatched subcommands to executables
+	ResponseFiles     bool     // expand "@path" args with args read from path
+	Deprecated        *Deprecation
+	AliasDeprecations map[string]*Deprecation
+	WarningFunc       func(*Warning) // called for each warning during Parse
 
 	// Reconfiguration (modify as needed)
 	Handler Handler
@@ -46,6 +59,22 @@ type Clic struct {
 	// Accessing (avoid modification)
 	FlagSet    *flagset.FlagSet
 	OperandSet *operandset.OperandSet
+
+	subIdx   map[string]*Clic
+	build    func() *Clic
+	pending  []func(*Clic)
+	restores []func()
+	lastPath []*Clic
+	flagVals map[*flagset.Flag]any
+	opVals   map[*operandset.Operand]any
+	flagSrcs map[*flagset.Flag]Provenance
+	argSrcs  bool
+	flagDeps map[*flagset.Flag]*Deprecation
+	calledAs string
+	passArgs []string
+	argAlias map[string][]string
+	inv      *Invocation
+	warnings []*Warning
 }
 
 // New returns an instance of Clic.
@@ -59,6 +88,8 @@ func New(h Handler, name string, subs ...*Clic) *Clic {
 		FlagSet:    flagset.New(name),
 		OperandSet: operandset.New(name),
 		Meta:       make(map[string]any),
+		flagVals:   make(map[*flagset.Flag]any),
+		opVals:     make(map[*operandset.Operand]any),
 	}
 
 	for _, sub := range subs {
@@ -77,43 +108,109 @@ func NewFromFunc(f HandlerFunc, name string, subs ...*Clic) *Clic {
 	return New(f, name, subs...)
 }
 
-// Parse resolves arguments to the relevant *Clic instance.
+// Parse resolves arguments to the relevant *Clic instance. If the ResetOnParse
+// field is set, values and state modified by the previous call are reset first
+// (see [Clic.Reset]).
+//
+// When a subcommand name directly follows the flags of a command, the
+// command's FlagSet parses only those flags, so its Parsed and Operands methods
+// do not report the subcommand args. The full args are available from the
+// [Invocation] (see [InvocationFrom]).
 func (c *Clic) Parse(args []string) (*Clic, error) {
-	resolved, err := parseCmdsAndFlags(c, args, c.FlagSet.Name())
+	if c.ResetOnParse {
+		c.resetParsed()
+	}
+
+	cmdArgs := args
+	if c.ResponseFiles {
+		var err error
+		if cmdArgs, err = expandResponseFiles(args, 0); err != nil {
+			return c, cerrs.NewError(cerrs.NewParseError(err))
+		}
+	}
+
+	resolved, err := parseCmdsAndFlags(c, cmdArgs, c.FlagSet.Name(), false)
+	path := cmdPath(c, resolved)
+	c.lastPath = path
+	clearFlagSources(path)
+
+	resolved.inv = nil
 	if err != nil {
+		resolved.argSrcs = false
+		resolved.warnings = nil
 		return resolved, err
 	}
 
-	if err := resolved.OperandSet.Parse(resolved.FlagSet.Operands()); err != nil {
-		return resolved, cerrs.NewError(cerrs.NewParseError(err))
+	c.recordWarnings(path)
+
+	if !resolved.Passthrough {
+		if err := resolved.parseOperands(c.Prompter, resolved.FlagSet.Operands()); err != nil {
+			return resolved, cerrs.NewError(cerrs.NewParseError(err))
+		}
 	}
 
+	resolved.inv = newInvocation(path, args)
+
 	return resolved, nil
 }
 
 // Flag adds a flag option. See [flagset.FlagSet.Flag] for more details like
 // compatible value types.
 func (c *Clic) Flag(val any, names, usage string) *flagset.Flag {
-	return c.FlagSet.Flag(val, names, usage)
+	conv := vtypes.ConvertCompatible(val)
+	c.restores = appendRestore(c.restores, val, conv)
+
+	flag := c.FlagSet.Flag(conv, names, usage)
+	c.flagVals[flag] = conv
+
+	return flag
 }
 
 // Operand adds an operand option. See [operandset.OperandSet.Operand] for more
 // details like compatible value types.
 func (c *Clic) Operand(val any, req bool, name, desc string) *operandset.Operand {
-	return c.OperandSet.Operand(val, req, name, desc)
+	conv := vtypes.ConvertCompatible(val)
+	c.restores = appendRestore(c.restores, val, conv)
+
+	op := c.OperandSet.Operand(conv, req, name, desc)
+	c.opVals[op] = conv
+
+	return op
+}
+
+// PassthroughArgs returns the args captured verbatim by the most recent call to
+// Parse that resolved to the Clic instance. Args are only captured when the
+// Passthrough field is set, in which case subcommands and operands are not
+// processed. Capturing begins at the first operand or after "--".
+func (c *Clic) PassthroughArgs() []string {
+	return c.passArgs
 }
 
 // Recursively applies the provided function to the current Clic instance and
-// all its subcommands recursively.
+// all its subcommands recursively. Application to unbuilt placeholders (see
+// [NewLazy]) is deferred until they are built.
 func (c *Clic) Recursively(fn func(*Clic)) {
+	if c.build != nil {
+		c.pending = append(c.pending, fn)
+		return
+	}
+
 	fn(c)
 	for _, sub := range c.subs {
 		sub.Recursively(fn)
 	}
 }
 
-// Handle calls the HandleCommand method on the set [Handler].
+// Handle calls the HandleCommand method on the set [Handler]. Details about the
+// most recent call to Parse that resolved to the Clic instance are added to the
+// context, and can be accessed using [InvocationFrom] and [CommandFrom].
 func (c *Clic) Handle(ctx context.Context) error {
+	inv := c.inv
+	if inv == nil {
+		inv = newInvocation(cmdPath(nil, c), nil)
+	}
+
+	ctx = context.WithValue(ctx, invocationKey{}, inv)
 	return c.Handler.HandleCommand(ctx)
 }
 
@@ -121,23 +218,108 @@ func (c *Clic) Handle(ctx context.Context) error {
 // ([NewUsageTmpl]) can be used as a reference for custom templates which should
 // be used to set the "Tmpl" field on Clic (likely using [*Clic.Recursively]).
 func (c *Clic) Usage() string {
+	c.materialize()
 	return c.Tmpl.String()
 }
 
-var errParseNoMatch = errors.New("parse: no command match")
+// WriteUsage writes usage text to w (e.g. [os.Stderr] or a pager). Unlike
+// Usage, template errors are returned rather than written.
+func (c *Clic) WriteUsage(w io.Writer) error {
+	c.materialize()
+	return c.Tmpl.ExecuteTo(w)
+}
+
+// lookupSub returns the subcommand matching the provided name or alias. Hits
+// in the index of subcommand names and aliases are verified, and misses are
+// checked against the subcommands directly so that names and aliases changed
+// after the index was built are found (and the index is rebuilt).
+func (c *Clic) lookupSub(name string) *Clic {
+	if sub := c.subIdx[name]; sub != nil && hasName(sub, name) {
+		return sub
+	}
 
-func parseCmdsAndFlags(c *Clic, args []string, cmdName string) (*Clic, error) {
+	for _, sub := range c.subs {
+		if hasName(sub, name) {
+			c.indexSubs()
+			return sub
+		}
+	}
+	return nil
+}
+
+func (c *Clic) indexSubs() {
+	c.subIdx = make(map[string]*Clic, len(c.subs))
+	for _, sub := range c.subs {
+		if _, ok := c.subIdx[sub.FlagSet.Name()]; !ok {
+			c.subIdx[sub.FlagSet.Name()] = sub
+		}
+		for _, alias := range sub.Aliases {
+			if _, ok := c.subIdx[alias]; !ok {
+				c.subIdx[alias] = sub
+			}
+		}
+	}
+}
+
+func hasName(c *Clic, name string) bool {
+	return name == c.FlagSet.Name() || slices.Contains(c.Aliases, name)
+}
+
+// hasSubOrAlias reports whether name resolves to a subcommand or user-defined
+// alias.
+func (c *Clic) hasSubOrAlias(name string) bool {
+	if _, ok := c.argAlias[name]; ok {
+		return true
+	}
+	return c.lookupSub(name) != nil
+}
+
+// cmdPath returns the commands from root to resolved (inclusive).
+func cmdPath(root, resolved *Clic) []*Clic {
+	var cmds []*Clic
+	for cmd := resolved; cmd != nil; cmd = cmd.parent {
+		cmds = append(cmds, cmd)
+		if cmd == root {
+			break
+		}
+	}
+
+	slices.Reverse(cmds)
+	return cmds
+}
+
+func parseCmdsAndFlags(c *Clic, args []string, cmdName string, interspersed bool) (*Clic, error) {
 	wrap := cerrs.NewError
 
-	called := cmdName == "" || cmdName == c.FlagSet.Name() || slices.Contains(c.Aliases, cmdName)
-	if !called {
-		return c, errParseNoMatch
+	c.materialize()
+	c.calledAs = cmdName
+
+	interspersed = interspersed || c.Interspersed
+	if interspersed && !c.Passthrough {
+		var err error
+		if args, err = intersperse(c, args); err != nil {
+			return c, wrap(cerrs.NewParseError(err))
+		}
+	}
+
+	// Only the leading flags are parsed when followed by a subcommand so that
+	// the remaining args are not resolved again at each level.
+	fsArgs, subCmdArgs := args, []string(nil)
+	if n := leadingFlagsLen(c, args); n >= 0 && !c.Passthrough && c.hasSubOrAlias(args[n]) {
+		fsArgs, subCmdArgs = args[:n], args[n:]
 	}
 
-	if err := c.FlagSet.Parse(args); err != nil {
+	if err := c.FlagSet.Parse(fsArgs); err != nil {
 		return c, wrap(cerrs.NewParseError(err))
 	}
-	subCmdArgs := c.FlagSet.Operands()
+	if subCmdArgs == nil {
+		subCmdArgs = c.FlagSet.Operands()
+	}
+
+	if c.Passthrough {
+		c.passArgs = slices.Clone(subCmdArgs)
+		return c, nil
+	}
 
 	if len(subCmdArgs) == 0 {
 		if c.SubRequired {
@@ -150,15 +332,26 @@ func parseCmdsAndFlags(c *Clic, args []string, cmdName string) (*Clic, error) {
 	subCmdName := subCmdArgs[0]
 	subCmdArgs = subCmdArgs[1:]
 
-	for _, sub := range c.Links.subs {
-		resolved, err := parseCmdsAndFlags(sub, subCmdArgs, subCmdName)
+	if _, ok := c.argAlias[subCmdName]; ok && c.lookupSub(subCmdName) == nil {
+		expanded, err := c.expandAlias(subCmdName)
 		if err != nil {
-			if errors.Is(err, errParseNoMatch) {
-				continue
-			}
-			return resolved, err
+			return c, wrap(cerrs.NewParseError(err))
+		}
+		subCmdName = expanded[0]
+		subCmdArgs = append(expanded[1:], subCmdArgs...)
+	}
+
+	if sub := c.lookupSub(subCmdName); sub != nil {
+		return parseCmdsAndFlags(sub, subCmdArgs, subCmdName, interspersed)
+	}
+
+	if c.Plugins != nil {
+		if path := c.Plugins.lookup(c, subCmdName); path != "" {
+			plugin := c.newPluginCmd(subCmdName, path)
+			plugin.calledAs = subCmdName
+			plugin.passArgs = slices.Clone(subCmdArgs)
+			return plugin, nil
 		}
-		return resolved, nil
 	}
 
 	if c.SubRequired {
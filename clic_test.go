@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"testing"
 
@@ -188,3 +189,217 @@ func (cmd *Cmd) HandleCommand(ctx context.Context) error {
 	fmt.Fprintf(cmd.buf, "%s", cmd.name)
 	return nil
 }
+
+func TestClicParseParentFlagSet(t *testing.T) {
+	buf := &bytes.Buffer{}
+	sub := NewCmdClic(buf, "subcmd", nil)
+	root := NewCmdClic(buf, "myapp", nil, sub)
+
+	if _, err := root.Parse([]string{"-n", "3", "subcmd", "--info=x"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := root.FlagSet.Parsed(), []string{"-n", "3"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("parsed: got: %q, want: %q", got, want)
+	}
+	if got := root.FlagSet.Operands(); len(got) != 0 {
+		t.Fatalf("operands: got: %q, want: none", got)
+	}
+	if got, want := sub.FlagSet.Parsed(), []string{"--info=x"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("sub parsed: got: %q, want: %q", got, want)
+	}
+}
+
+func TestClicParseInterspersed(t *testing.T) {
+	tt := []struct {
+		name  string
+		args  []string
+		force bool
+		level int
+		opnds []string
+	}{
+		{"flags first", []string{"copy", "-f", "src", "dst"}, true, 0, []string{"src", "dst"}},
+		{"flags after", []string{"copy", "src", "dst", "--force"}, true, 0, []string{"src", "dst"}},
+		{"flag value after", []string{"copy", "src", "-l", "3", "dst"}, false, 3, []string{"src", "dst"}},
+		{"combined shorts", []string{"copy", "src", "-fl", "2", "dst"}, true, 2, []string{"src", "dst"}},
+		{"terminated", []string{"copy", "src", "--", "--force"}, false, 0, []string{"src", "--force"}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				force    bool
+				level    int
+				src, dst string
+			)
+
+			cp := New(nil, "copy")
+			cp.Flag(&force, "force|f", "")
+			cp.Flag(&level, "level|l", "")
+			cp.Operand(&src, true, "src", "")
+			cp.Operand(&dst, true, "dst", "")
+
+			root := New(nil, "myapp", cp)
+			root.Interspersed = true
+
+			resolved, err := root.Parse(tc.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resolved != cp {
+				t.Fatalf("resolved: got: %v, want: copy", resolved.FlagSet.Name())
+			}
+
+			if force != tc.force || level != tc.level {
+				t.Fatalf("flags: got: %v %v, want: %v %v", force, level, tc.force, tc.level)
+			}
+
+			if got := []string{src, dst}; !reflect.DeepEqual(got, tc.opnds) {
+				t.Fatalf("operands: got: %v, want: %v", got, tc.opnds)
+			}
+		})
+	}
+
+	t.Run("trailing flag missing value", func(t *testing.T) {
+		var level int
+		var src string
+
+		cp := New(nil, "copy")
+		cp.Flag(&level, "level|l", "")
+		cp.Operand(&src, true, "src", "")
+
+		root := New(nil, "myapp", cp)
+		root.Interspersed = true
+
+		_, err := root.Parse([]string{"copy", "src", "-l"})
+		if !errors.Is(err, CauseParseFlagResolve) || !errors.Is(err, CauseParseFlagValueMissing) {
+			t.Fatalf("got: %v, want: %v", err, CauseParseFlagValueMissing)
+		}
+		if src != "" || level != 0 {
+			t.Fatalf("vals: got: %q %d, want: empty", src, level)
+		}
+	})
+}
+
+func TestClicParseLine(t *testing.T) {
+	var (
+		info string
+		opnd string
+	)
+
+	sub := New(nil, "subcmd")
+	sub.Flag(&info, "info", "")
+	sub.Operand(&opnd, true, "opnd", "")
+
+	root := New(nil, "myapp", sub)
+
+	resolved, err := root.ParseLine(`subcmd --info="a b" 'c d'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved != sub || info != "a b" || opnd != "c d" {
+		t.Fatalf("got: %v %q %q, want: subcmd %q %q", resolved.FlagSet.Name(), info, opnd, "a b", "c d")
+	}
+
+	if _, err := root.ParseLine(`subcmd "c d`); !errors.Is(err, CauseParseLineUnterminated) {
+		t.Fatalf("error: got: %v, want: %v", err, CauseParseLineUnterminated)
+	}
+}
+
+func TestClicParseAliasChanged(t *testing.T) {
+	sub := New(nil, "subcmd")
+	root := New(nil, "myapp", sub)
+
+	if resolved, err := root.Parse([]string{"subcmd"}); err != nil || resolved != sub {
+		t.Fatalf("name: got: %v %v, want: subcmd <nil>", resolved.FlagSet.Name(), err)
+	}
+
+	sub.Aliases = append(sub.Aliases, "sc")
+	if resolved, err := root.Parse([]string{"sc"}); err != nil || resolved != sub {
+		t.Fatalf("added alias: got: %v %v, want: subcmd <nil>", resolved.FlagSet.Name(), err)
+	}
+
+	sub.Aliases = nil
+	if resolved, _ := root.Parse([]string{"sc"}); resolved != root {
+		t.Fatalf("removed alias: got: %v, want: myapp", resolved.FlagSet.Name())
+	}
+}
+
+func newBenchClic(name string, subs ...*Clic) *Clic {
+	var (
+		info string
+		num  int
+	)
+
+	c := New(HandlerFunc(func(context.Context) error { return nil }), name, subs...)
+	c.Flag(&info, "info|i", "")
+	c.Flag(&num, "num|n", "")
+	return c
+}
+
+func BenchmarkClicParseWide(b *testing.B) {
+	for _, width := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprint(width), func(b *testing.B) {
+			var subs []*Clic
+			for i := 0; i < width; i++ {
+				subs = append(subs, newBenchClic(fmt.Sprintf("sub%d", i)))
+			}
+			root := newBenchClic("myapp", subs...)
+			args := []string{"--info=x", fmt.Sprintf("sub%d", width-1), "-n", "3"}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := root.Parse(args); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkClicParseDeep(b *testing.B) {
+	for _, depth := range []int{5, 20, 50} {
+		b.Run(fmt.Sprint(depth), func(b *testing.B) {
+			cmd := newBenchClic(fmt.Sprintf("sub%d", depth))
+			args := []string{cmd.FlagSet.Name()}
+			for i := depth - 1; i > 0; i-- {
+				sibling := newBenchClic(fmt.Sprintf("other%d", i))
+				cmd = newBenchClic(fmt.Sprintf("sub%d", i), sibling, cmd)
+				args = append([]string{cmd.FlagSet.Name(), "-i", "x"}, args...)
+			}
+			root := newBenchClic("myapp", cmd)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := root.Parse(args); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkClicUsage(b *testing.B) {
+	var subs []*Clic
+	for i := 0; i < 20; i++ {
+		sub := newBenchClic(fmt.Sprintf("sub%d", i))
+		sub.Category = "Main"
+		subs = append(subs, sub)
+	}
+	root := newBenchClic("myapp", subs...)
+	root.Description = "Benchmark usage output"
+	root.SubCmdCatsSort = []string{"Main|Main commands"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := root.WriteUsage(io.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Command clicdiff compares two command tree specs (as exported by encoding a
+// [clic.Spec] as JSON) and reports breaking and additive changes. The exit code
+// is 1 when breaking changes are found, and 2 when the comparison cannot run.
+//
+//	clicdiff [--additive] <old_spec> <new_spec>
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/daved/clic"
+)
+
+var errBreaking = errors.New("breaking changes found")
+
+func main() {
+	var (
+		additive bool
+		oldPath  string
+		newPath  string
+	)
+
+	run := func(ctx context.Context) error {
+		prev, err := readSpec(oldPath)
+		if err != nil {
+			return err
+		}
+
+		next, err := readSpec(newPath)
+		if err != nil {
+			return err
+		}
+
+		changes := clic.DiffSpecs(prev, next)
+		for _, change := range changes {
+			if change.Kind == clic.ChangeAdditive && !additive {
+				continue
+			}
+			fmt.Println(change)
+		}
+
+		if len(changes.Breaking()) > 0 {
+			return errBreaking
+		}
+		return nil
+	}
+
+	root := clic.NewFromFunc(run, "clicdiff")
+	root.Description = "Report compatibility changes between two command tree specs."
+	root.Flag(&additive, "a|additive", "Also print additive changes.")
+	root.Operand(&oldPath, true, "old_spec", "Path to the previous spec (JSON).")
+	root.Operand(&newPath, true, "new_spec", "Path to the current spec (JSON).")
+
+	cmd, err := root.Parse(os.Args[1:])
+	if err != nil {
+		fmt.Fprint(os.Stderr, cmd.Usage())
+		fmt.Fprintf(os.Stderr, "\n%v\n", clic.UserFriendlyError(err))
+		os.Exit(2)
+	}
+
+	if err := cmd.Handle(context.Background()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		if errors.Is(err, errBreaking) {
+			os.Exit(1)
+		}
+		os.Exit(2)
+	}
+}
+
+func readSpec(path string) (*clic.Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &clic.Spec{}
+	if err := json.Unmarshal(data, spec); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,71 @@
+package clic
+
+import (
+	"strings"
+)
+
+// Complete returns completion candidates for the final element of args, which
+// is treated as a partial word (it may be empty). Preceding args are resolved
+// against the command tree starting at the Clic instance. Subcommand names are
+// suggested for words, and flag names are suggested for words beginning with a
+// hyphen. Hidden and deprecated items are not suggested.
+func (c *Clic) Complete(args []string) []string {
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	words, partial := args[:len(args)-1], args[len(args)-1]
+
+	cmd := c
+	cmd.materialize()
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if word == "--" {
+			return nil
+		}
+		if isFlagArg(word) {
+			if next, _ := scanFlagArg(cmd, word, nil); next {
+				i++ // skip the flag value
+			}
+			continue
+		}
+
+		sub := cmd.lookupSub(word)
+		if sub == nil {
+			return nil // operands do not complete
+		}
+		cmd = sub
+		cmd.materialize()
+	}
+
+	var out []string
+
+	if strings.HasPrefix(partial, "-") {
+		for _, flag := range cmd.FlagSet.Flags() {
+			if flag.HideUsage {
+				continue
+			}
+			for _, long := range flag.Longs() {
+				out = appendIfPrefixed(out, "--"+long, partial)
+			}
+			for _, short := range flag.Shorts() {
+				out = appendIfPrefixed(out, "-"+short, partial)
+			}
+		}
+		return out
+	}
+
+	for _, sub := range cmd.subs {
+		if sub.HideUsage || sub.Deprecated != nil {
+			continue
+		}
+		out = appendIfPrefixed(out, sub.FlagSet.Name(), partial)
+	}
+	return out
+}
+
+func appendIfPrefixed(out []string, s, prefix string) []string {
+	if strings.HasPrefix(s, prefix) {
+		return append(out, s)
+	}
+	return out
+}
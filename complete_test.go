@@ -0,0 +1,51 @@
+package clic
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClicComplete(t *testing.T) {
+	var (
+		force bool
+		info  string
+	)
+
+	var num int
+
+	status := New(nil, "status")
+	status.Flag(&force, "force|f", "")
+	status.Flag(&info, "info", "").HideUsage = true
+
+	stop := New(nil, "stop|halt")
+	hidden := New(nil, "stash")
+	hidden.HideUsage = true
+
+	root := New(nil, "myapp", status, stop, hidden)
+	root.Flag(&num, "num|n", "")
+
+	tt := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"none", nil, []string{"status", "stop"}},
+		{"prefix", []string{"st"}, []string{"status", "stop"}},
+		{"prefix narrow", []string{"sta"}, []string{"status"}},
+		{"after flag value", []string{"-n", "3", "st"}, []string{"status", "stop"}},
+		{"after flag equals", []string{"--num=3", "st"}, []string{"status", "stop"}},
+		{"flags", []string{"status", "-"}, []string{"--force", "-f"}},
+		{"long flags", []string{"status", "--"}, []string{"--force"}},
+		{"alias path", []string{"halt", ""}, nil},
+		{"operand", []string{"nope", ""}, nil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got := root.Complete(tc.args)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got: %v, want: %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package clic
+
+import (
+	"context"
+	"io"
+	"os"
+)
+
+// Invocation holds details about how a command was invoked.
+type Invocation struct {
+	Cmd         *Clic
+	Path        []string // command names from the parsed command to Cmd
+	Args        []string // args provided to Parse
+	Operands    []string // args provided to Cmd's OperandSet
+	Passthrough []string // see [Clic.PassthroughArgs]
+}
+
+type invocationKey struct{}
+
+// InvocationFrom returns the Invocation added to the context by [Clic.Handle],
+// or nil if it is not set.
+func InvocationFrom(ctx context.Context) *Invocation {
+	inv, _ := ctx.Value(invocationKey{}).(*Invocation)
+	return inv
+}
+
+// CommandFrom returns the Clic instance being handled, or nil if it is not set.
+func CommandFrom(ctx context.Context) *Clic {
+	if inv := InvocationFrom(ctx); inv != nil {
+		return inv.Cmd
+	}
+	return nil
+}
+
+func newInvocation(path []*Clic, args []string) *Invocation {
+	cmd := path[len(path)-1]
+
+	inv := &Invocation{
+		Cmd:         cmd,
+		Args:        args,
+		Operands:    cmd.OperandSet.Parsed(),
+		Passthrough: cmd.passArgs,
+	}
+	for _, c := range path {
+		inv.Path = append(inv.Path, c.FlagSet.Name())
+	}
+
+	return inv
+}
+
+// Stdio holds the standard streams a handler should use. Nil fields are
+// treated as their [os] counterparts (e.g. [os.Stdout]).
+type Stdio struct {
+	In  io.Reader
+	Out io.Writer
+	Err io.Writer
+}
+
+type stdioKey struct{}
+
+// WithStdio returns a copy of ctx carrying the provided streams. It allows
+// output to be captured per request (e.g. by [NewHTTPHandler]).
+func WithStdio(ctx context.Context, s Stdio) context.Context {
+	return context.WithValue(ctx, stdioKey{}, s)
+}
+
+// StdioFrom returns the streams added to the context by [WithStdio]. Unset
+// streams are returned as [os.Stdin], [os.Stdout], and [os.Stderr].
+func StdioFrom(ctx context.Context) Stdio {
+	s, _ := ctx.Value(stdioKey{}).(Stdio)
+	if s.In == nil {
+		s.In = os.Stdin
+	}
+	if s.Out == nil {
+		s.Out = os.Stdout
+	}
+	if s.Err == nil {
+		s.Err = os.Stderr
+	}
+	return s
+}
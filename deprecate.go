@@ -0,0 +1,139 @@
+package clic
+
+import (
+	"strings"
+
+	"github.com/daved/flagset"
+)
+
+// Deprecation holds details about a deprecated command, alias, or flag.
+// Deprecated items are still accepted by Parse, are hidden from usage output,
+// and are reported as warnings when used.
+type Deprecation struct {
+	Message        string
+	Replacement    string // optional
+	RemovalVersion string // optional
+}
+
+// String implements [fmt.Stringer].
+func (d *Deprecation) String() string {
+	return d.Text(nil)
+}
+
+// Text returns the details of the deprecation using the text of the provided
+// catalog.
+func (d *Deprecation) Text(cat Catalog) string {
+	var parts []string
+	if d.Message != "" {
+		parts = append(parts, d.Message)
+	}
+	if d.Replacement != "" {
+		parts = append(parts, cat.Sprintf(MsgUseInstead, d.Replacement))
+	}
+	if d.RemovalVersion != "" {
+		parts = append(parts, cat.Sprintf(MsgRemovalVersion, d.RemovalVersion))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Warning kinds. Each is also a message key (see [Catalog]).
+const (
+	WarningKindCommand = "command"
+	WarningKindAlias   = "alias"
+	WarningKindFlag    = "flag"
+)
+
+// Warning describes the use of a deprecated item during parsing.
+type Warning struct {
+	Cmd         *Clic
+	Kind        string // one of the WarningKind values
+	Name        string // as used (e.g. "--old-flag")
+	Deprecation *Deprecation
+}
+
+// String implements [fmt.Stringer]. The catalog of Cmd is used.
+func (w *Warning) String() string {
+	var cat Catalog
+	if w.Cmd != nil {
+		cat = w.Cmd.Catalog
+	}
+	return w.Text(cat)
+}
+
+// Text returns a description of the warning using the text of the provided
+// catalog.
+func (w *Warning) Text(cat Catalog) string {
+	s := cat.Sprintf(MsgIsDeprecated, cat.Text(w.Kind), w.Name)
+	if d := w.Deprecation.Text(cat); d != "" {
+		s += ": " + d
+	}
+	return s
+}
+
+// DeprecateFlag marks the flag with the provided name (long or short) as
+// deprecated, and hides it from usage output. The affected flag is returned,
+// or nil if no flag matches.
+func (c *Clic) DeprecateFlag(name string, d *Deprecation) *flagset.Flag {
+	flag := c.FlagSet.Lookup(name)
+	if flag == nil {
+		return nil
+	}
+
+	if c.flagDeps == nil {
+		c.flagDeps = make(map[*flagset.Flag]*Deprecation)
+	}
+	c.flagDeps[flag] = d
+	flag.HideUsage = true
+
+	return flag
+}
+
+// Warnings returns the warnings recorded by the most recent call to Parse that
+// resolved to the Clic instance.
+func (c *Clic) Warnings() []*Warning {
+	return c.warnings
+}
+
+func (c *Clic) aliasDeprecation(name string) *Deprecation {
+	if c.AliasDeprecations == nil {
+		return nil
+	}
+	return c.AliasDeprecations[name]
+}
+
+// recordWarnings sets the warnings of the last command in the parsed path, and
+// reports each to the WarningFunc of the Clic instance (if set).
+func (c *Clic) recordWarnings(path []*Clic) {
+	resolved := path[len(path)-1]
+	resolved.warnings = collectWarnings(path)
+
+	if c.WarningFunc != nil {
+		for _, warn := range resolved.warnings {
+			c.WarningFunc(warn)
+		}
+	}
+}
+
+// collectWarnings gathers deprecation warnings for the parsed command path.
+func collectWarnings(path []*Clic) []*Warning {
+	var warns []*Warning
+	for _, cmd := range path {
+		if cmd.Deprecated != nil {
+			warns = append(warns, &Warning{cmd, WarningKindCommand, cmd.FlagSet.Name(), cmd.Deprecated})
+		}
+		if d := cmd.aliasDeprecation(cmd.calledAs); d != nil {
+			warns = append(warns, &Warning{cmd, WarningKindAlias, cmd.calledAs, d})
+		}
+
+		if len(cmd.flagDeps) == 0 {
+			continue
+		}
+		for _, use := range usedFlags(cmd) {
+			if d := cmd.flagDeps[use.flag]; d != nil {
+				warns = append(warns, &Warning{cmd, WarningKindFlag, use.hint(), d})
+			}
+		}
+	}
+
+	return warns
+}
@@ -0,0 +1,67 @@
+package clic
+
+import (
+	"testing"
+)
+
+func TestWarningText(t *testing.T) {
+	dep := &Deprecation{Message: "ambiguous", Replacement: "--force", RemovalVersion: "v2"}
+	cat := Catalog{
+		MsgFlag:           "Option",
+		MsgIsDeprecated:   "%s %q ist veraltet",
+		MsgUseInstead:     "stattdessen %q verwenden",
+		MsgRemovalVersion: "wird in %s entfernt",
+	}
+
+	tt := []struct {
+		name string
+		cat  Catalog
+		want string
+	}{
+		{
+			name: "default",
+			want: `flag "-f" is deprecated: ambiguous; use "--force" instead; to be removed in v2`,
+		},
+		{
+			name: "catalog",
+			cat:  cat,
+			want: `Option "-f" ist veraltet: ambiguous; stattdessen "--force" verwenden; wird in v2 entfernt`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := New(nil, "myapp")
+			cmd.Catalog = tc.cat
+			w := &Warning{cmd, WarningKindFlag, "-f", dep}
+
+			if got := w.String(); got != tc.want {
+				t.Fatalf("got: %q, want: %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClicWarnings(t *testing.T) {
+	var num int
+
+	sub := New(nil, "sub|old")
+	sub.Flag(&num, "x", "")
+	sub.AliasDeprecations = map[string]*Deprecation{"old": {Replacement: "sub"}}
+
+	root := New(nil, "myapp", sub)
+
+	if _, err := root.Parse([]string{"old"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(sub.Warnings()); got != 1 {
+		t.Fatalf("deprecated alias: got: %d warnings, want: 1", got)
+	}
+
+	if _, err := root.Parse([]string{"sub", "--x=bad"}); err == nil {
+		t.Fatal("failed parse: got: nil error")
+	}
+	if got := sub.Warnings(); got != nil {
+		t.Fatalf("failed parse: got: %v, want: none", got)
+	}
+}
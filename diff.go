@@ -0,0 +1,215 @@
+package clic
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ChangeKind describes the compatibility impact of a [Change].
+type ChangeKind int
+
+// ChangeKind values.
+const (
+	ChangeAdditive ChangeKind = iota
+	ChangeBreaking
+)
+
+// String implements [fmt.Stringer].
+func (k ChangeKind) String() string {
+	if k == ChangeBreaking {
+		return "breaking"
+	}
+	return "additive"
+}
+
+// Change describes a single difference between two command tree specs.
+type Change struct {
+	Kind ChangeKind
+	Path string // command path (e.g. "myapp sub")
+	Desc string
+}
+
+// String implements [fmt.Stringer].
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s: %s", c.Kind, c.Path, c.Desc)
+}
+
+// Changes is a list of [Change] values.
+type Changes []Change
+
+// Breaking returns only the breaking changes.
+func (cs Changes) Breaking() Changes {
+	var out Changes
+	for _, c := range cs {
+		if c.Kind == ChangeBreaking {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// DiffSpecs compares two command tree specs and classifies each difference as
+// breaking or additive. Removed commands, aliases, flags, and operands, flag
+// and operand type changes, operands made required, newly required
+// subcommands, and added required operands are breaking. All other structural
+// differences are additive. Subcommands are matched by name, and then by alias
+// so that renaming a command while keeping its old name as an alias is
+// additive. Descriptions and defaults are not compared.
+func DiffSpecs(prev, next *Spec) Changes {
+	var cs Changes
+	diffSpecs(&cs, prev, next, prev.Name)
+	return cs
+}
+
+func diffSpecs(cs *Changes, prev, next *Spec, path string) {
+	add := func(kind ChangeKind, format string, args ...any) {
+		*cs = append(*cs, Change{kind, path, fmt.Sprintf(format, args...)})
+	}
+
+	switch {
+	case prev.Name == next.Name:
+	case slices.Contains(next.Aliases, prev.Name):
+		add(ChangeAdditive, "command renamed to %q (previous name kept as alias)", next.Name)
+	default:
+		add(ChangeBreaking, "command renamed to %q", next.Name)
+	}
+
+	for _, alias := range prev.Aliases {
+		if alias != next.Name && !slices.Contains(next.Aliases, alias) {
+			add(ChangeBreaking, "alias %q removed", alias)
+		}
+	}
+	for _, alias := range next.Aliases {
+		if alias != prev.Name && !slices.Contains(prev.Aliases, alias) {
+			add(ChangeAdditive, "alias %q added", alias)
+		}
+	}
+
+	if !prev.SubRequired && next.SubRequired {
+		add(ChangeBreaking, "subcommand made required")
+	}
+	if prev.SubRequired && !next.SubRequired {
+		add(ChangeAdditive, "subcommand made optional")
+	}
+
+	diffFlagSpecs(add, prev.Flags, next.Flags)
+	diffOperandSpecs(add, prev.Operands, next.Operands)
+
+	matched := make(map[*Spec]bool)
+	for _, prevSub := range prev.SubCmds {
+		nextSub := lookupSpec(next.SubCmds, prevSub.Name)
+		if nextSub == nil {
+			add(ChangeBreaking, "subcommand %q removed", prevSub.Name)
+			continue
+		}
+		matched[nextSub] = true
+		diffSpecs(cs, prevSub, nextSub, path+" "+prevSub.Name)
+	}
+	for _, nextSub := range next.SubCmds {
+		if !matched[nextSub] {
+			add(ChangeAdditive, "subcommand %q added", nextSub.Name)
+		}
+	}
+}
+
+func diffFlagSpecs(add func(ChangeKind, string, ...any), prev, next []*FlagSpec) {
+	for _, prevFlag := range prev {
+		nextFlag := lookupFlagSpec(next, prevFlag.names())
+		if nextFlag == nil {
+			add(ChangeBreaking, "flag %s removed", flagSpecHint(prevFlag.names()))
+			continue
+		}
+
+		for _, name := range prevFlag.names() {
+			if !slices.Contains(nextFlag.names(), name) {
+				add(ChangeBreaking, "flag name %s removed", flagSpecHint([]string{name}))
+			}
+		}
+		for _, name := range nextFlag.names() {
+			if !slices.Contains(prevFlag.names(), name) {
+				add(ChangeAdditive, "flag name %s added", flagSpecHint([]string{name}))
+			}
+		}
+
+		if prevFlag.Type != nextFlag.Type {
+			add(
+				ChangeBreaking, "flag %s type changed from %q to %q",
+				flagSpecHint(prevFlag.names()), prevFlag.Type, nextFlag.Type,
+			)
+		}
+	}
+
+	for _, nextFlag := range next {
+		if lookupFlagSpec(prev, nextFlag.names()) == nil {
+			add(ChangeAdditive, "flag %s added", flagSpecHint(nextFlag.names()))
+		}
+	}
+}
+
+func diffOperandSpecs(add func(ChangeKind, string, ...any), prev, next []*OperandSpec) {
+	for i, prevOp := range prev {
+		if i >= len(next) {
+			add(ChangeBreaking, "operand %q removed", prevOp.Name)
+			continue
+		}
+
+		nextOp := next[i]
+		if prevOp.Type != nextOp.Type {
+			add(ChangeBreaking, "operand %q type changed from %q to %q", prevOp.Name, prevOp.Type, nextOp.Type)
+		}
+		if !prevOp.Required && nextOp.Required {
+			add(ChangeBreaking, "operand %q made required", nextOp.Name)
+		}
+		if prevOp.Required && !nextOp.Required {
+			add(ChangeAdditive, "operand %q made optional", nextOp.Name)
+		}
+	}
+
+	for i := len(prev); i < len(next); i++ {
+		if next[i].Required {
+			add(ChangeBreaking, "required operand %q added", next[i].Name)
+			continue
+		}
+		add(ChangeAdditive, "operand %q added", next[i].Name)
+	}
+}
+
+// lookupSpec returns the spec with the provided name, or else the first spec
+// with a matching alias.
+func lookupSpec(specs []*Spec, name string) *Spec {
+	for _, s := range specs {
+		if s.Name == name {
+			return s
+		}
+	}
+	for _, s := range specs {
+		if slices.Contains(s.Aliases, name) {
+			return s
+		}
+	}
+	return nil
+}
+
+func lookupFlagSpec(flags []*FlagSpec, names []string) *FlagSpec {
+	for _, flag := range flags {
+		for _, name := range names {
+			if slices.Contains(flag.names(), name) {
+				return flag
+			}
+		}
+	}
+	return nil
+}
+
+func flagSpecHint(names []string) string {
+	var hints []string
+	for _, name := range names {
+		pre := "--"
+		if len([]rune(name)) == 1 {
+			pre = "-"
+		}
+		hints = append(hints, pre+name)
+	}
+	return strings.Join(hints, "|")
+}
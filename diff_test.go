@@ -0,0 +1,145 @@
+package clic
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffSpecs(t *testing.T) {
+	newTree := func(mod func(root, sub *Clic)) *Spec {
+		var (
+			info string
+			num  int
+			opnd string
+		)
+
+		sub := New(nil, "subcmd|sc")
+		sub.Flag(&info, "info|i", "")
+		sub.Operand(&opnd, false, "opnd", "")
+
+		root := New(nil, "myapp", sub)
+		root.Flag(&num, "num|n", "")
+
+		if mod != nil {
+			mod(root, sub)
+		}
+
+		return NewSpec(root)
+	}
+
+	tt := []struct {
+		name string
+		mod  func(root, sub *Clic)
+		want Changes
+	}{
+		{
+			name: "none",
+		},
+		{
+			name: "subcmd removed",
+			mod: func(root, sub *Clic) {
+				root.subs = nil
+			},
+			want: Changes{
+				{ChangeBreaking, "myapp", `subcommand "subcmd" removed`},
+			},
+		},
+		{
+			name: "subcmd added",
+			mod: func(root, sub *Clic) {
+				root.subs = append(root.subs, New(nil, "other"))
+			},
+			want: Changes{
+				{ChangeAdditive, "myapp", `subcommand "other" added`},
+			},
+		},
+		{
+			name: "alias removed and added",
+			mod: func(root, sub *Clic) {
+				sub.Aliases = []string{"s"}
+			},
+			want: Changes{
+				{ChangeBreaking, "myapp subcmd", `alias "sc" removed`},
+				{ChangeAdditive, "myapp subcmd", `alias "s" added`},
+			},
+		},
+		{
+			name: "flag removed and type changed",
+			mod: func(root, sub *Clic) {
+				var num string
+				sub.FlagSet = New(nil, "subcmd").FlagSet
+				root.FlagSet = New(nil, "myapp").FlagSet
+				root.Flag(&num, "num", "")
+			},
+			want: Changes{
+				{ChangeBreaking, "myapp", "flag name -n removed"},
+				{ChangeBreaking, "myapp", `flag --num|-n type changed from "int" to "string"`},
+				{ChangeBreaking, "myapp subcmd", "flag --info|-i removed"},
+			},
+		},
+		{
+			name: "operand made required",
+			mod: func(root, sub *Clic) {
+				var opnd, more string
+				sub.OperandSet = New(nil, "subcmd").OperandSet
+				sub.Operand(&opnd, true, "opnd", "")
+				sub.Operand(&more, false, "more", "")
+			},
+			want: Changes{
+				{ChangeBreaking, "myapp subcmd", `operand "opnd" made required`},
+				{ChangeAdditive, "myapp subcmd", `operand "more" added`},
+			},
+		},
+		{
+			name: "operand type changed",
+			mod: func(root, sub *Clic) {
+				var opnd int
+				sub.OperandSet = New(nil, "subcmd").OperandSet
+				sub.Operand(&opnd, false, "opnd", "")
+			},
+			want: Changes{
+				{ChangeBreaking, "myapp subcmd", `operand "opnd" type changed from "string" to "int"`},
+			},
+		},
+		{
+			name: "subcmd renamed with old name kept as alias",
+			mod: func(root, sub *Clic) {
+				sub.FlagSet = New(nil, "sub").FlagSet
+				sub.Flag(new(string), "info|i", "")
+				sub.Aliases = []string{"subcmd", "sc"}
+			},
+			want: Changes{
+				{ChangeAdditive, "myapp subcmd", `command renamed to "sub" (previous name kept as alias)`},
+			},
+		},
+		{
+			name: "subcmd renamed",
+			mod: func(root, sub *Clic) {
+				sub.FlagSet = New(nil, "sub").FlagSet
+				sub.Flag(new(string), "info|i", "")
+			},
+			want: Changes{
+				{ChangeBreaking, "myapp", `subcommand "subcmd" removed`},
+				{ChangeAdditive, "myapp", `subcommand "sub" added`},
+			},
+		},
+		{
+			name: "subcmd made required",
+			mod: func(root, sub *Clic) {
+				root.SubRequired = true
+			},
+			want: Changes{
+				{ChangeBreaking, "myapp", "subcommand made required"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got := DiffSpecs(newTree(nil), newTree(tc.mod))
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got: %v, want: %v", got, tc.want)
+			}
+		})
+	}
+}
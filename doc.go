@@ -11,12 +11,18 @@
 //
 //	command --flag=flag-value subcommand -f flag-value operand_a operand_b
 //
+// Flags are not processed after operands unless the Interspersed field of Clic
+// is set (e.g. "copy src dst --force"). Arguments following "--" are always
+// treated as operands.
+//
 // # Custom Templating
 //
 // The [Tmpl] type eases custom templating. Custom data can be attached to
 // instances of Clic, FlagSet, Flag, OperandSet, and Operand via their Meta
 // fields for use in templates. The default template construction function
-// ([NewUsageTmpl]) can be used as a reference for custom templates.
+// ([NewUsageTmpl]) can be used as a reference for custom templates. Alternative
+// styles ([NewCompactUsageTmpl], [NewGNUUsageTmpl], and [NewDocoptUsageTmpl])
+// can be applied to a command tree using [Clic.SetUsageStyle].
 //
 // # Designed To Be...
 //
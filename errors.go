@@ -2,7 +2,8 @@ package clic
 
 import (
 	"errors"
-	"fmt"
+	"io/fs"
+	"strings"
 
 	"github.com/daved/flagset"
 	"github.com/daved/operandset"
@@ -12,13 +13,59 @@ import (
 // ErrSubCmdRequired signals that a subcommand is required and not set.
 var ErrSubCmdRequired = errors.New("subcommand required")
 
+// ErrLineUnterminated signals that a line ends within quotes or an escape.
+var ErrLineUnterminated = errors.New("unterminated quote or escape")
+
+// Alias errors are returned when user-defined aliases are invalid.
+var (
+	ErrAliasInvalid        = errors.New("alias invalid")
+	ErrAliasShadowsCommand = errors.New("alias shadows command")
+	ErrAliasRecursive      = errors.New("alias recursive")
+	ErrAliasUnresolved     = errors.New("alias unresolved")
+)
+
+// ErrFlagValueMissing signals that a flag provided after operands is missing
+// its value. It is returned when parsing interspersed flags.
+var ErrFlagValueMissing = errors.New("flag value missing")
+
+// ErrResponseFileDepth signals that response files are nested too deeply.
+var ErrResponseFileDepth = errors.New("response file depth limit reached")
+
+// Validation errors are reported by [Clic.Validate].
+var (
+	ErrNameConflict    = errors.New("name conflict")
+	ErrMultipleParents = errors.New("multiple parents")
+	ErrOperandOrder    = errors.New("operand order")
+	ErrFlagConflict    = errors.New("flag conflict")
+	ErrCategoryUnknown = errors.New("category unknown")
+)
+
+// ErrExampleMismatch signals that an example does not resolve to the command
+// it documents. It is reported by [Clic.VerifyExamples].
+var ErrExampleMismatch = errors.New("example mismatch")
+
+// ErrHandlerMissing signals that a resolved command has no handler (e.g. it
+// only groups subcommands).
+var ErrHandlerMissing = errors.New("handler missing")
+
+// ErrValueUntracked signals that a flag or operand value is not available to
+// Clic (i.e. it was not added using [Clic.Flag] or [Clic.Operand]).
+var ErrValueUntracked = errors.New("value untracked")
+
 // Cause values are provided for documentation, and to allow callers to easily
 // detect error conditions using a switch/case and [errors.Is]. If error
 // inspection is required, use [errors.As].
 var (
 	CauseParseSubCmdRequired   = ErrSubCmdRequired
+	CauseParseLineUnterminated = ErrLineUnterminated
+	CauseParseAlias            = &AliasError{}
+	CauseParseAliasRecursive   = ErrAliasRecursive  // from AliasError
+	CauseParseAliasUnresolved  = ErrAliasUnresolved // from AliasError
+	CauseParseResponseFile     = &ResponseFileError{}
+	CauseParseResponseDepth    = ErrResponseFileDepth // from ResponseFileError
 	CauseParseFlagResolve      = &flagset.ResolveError{}
 	CauseParseFlagUnrecognized = flagset.ErrFlagUnrecognized
+	CauseParseFlagValueMissing = ErrFlagValueMissing // from Flag Resolve
 	CauseParseOperandResolve   = &operandset.ResolveError{}
 	CauseParseOperandRequired  = operandset.ErrOperandRequired
 	CauseParseHydrateError     = &vtypes.HydrateError{}     // from Flag and Operand Resolve
@@ -27,43 +74,81 @@ var (
 )
 
 // UserFriendlyError returns a new error containing a plain language message.
+// See [Catalog.UserFriendlyError] for localized messages.
 func UserFriendlyError(err error) error {
+	return Catalog(nil).UserFriendlyError(err)
+}
+
+// UserFriendlyError returns a new error containing a plain language message
+// using the text held by the catalog.
+func (cat Catalog) UserFriendlyError(err error) error {
+	if rfErr := (*ResponseFileError)(nil); errors.As(err, &rfErr) {
+		return cat.friendlyResponseFileError(rfErr)
+	}
+
 	if errors.Is(err, ErrSubCmdRequired) {
-		return errors.New("A subcommand is required")
+		return errors.New(cat.Text(MsgSubCmdRequired))
+	}
+
+	if errors.Is(err, ErrLineUnterminated) {
+		return errors.New(cat.Text(MsgLineUnterminated))
+	}
+
+	if aliasErr := (*AliasError)(nil); errors.As(err, &aliasErr) {
+		if errors.Is(aliasErr, ErrAliasRecursive) {
+			return errors.New(cat.Sprintf(MsgAliasRecursive, aliasErr.Name, strings.Join(aliasErr.Chain, " -> ")))
+		}
+		return errors.New(cat.Sprintf(MsgAliasUnresolved, aliasErr.Name, aliasErr.Chain[len(aliasErr.Chain)-1]))
 	}
 
 	if resErr := (*flagset.ResolveError)(nil); errors.As(err, &resErr) {
 		if errors.Is(resErr, flagset.ErrFlagUnrecognized) {
-			return fmt.Errorf("Unrecognized flag %q", resErr.FlagName)
+			return errors.New(cat.Sprintf(MsgFlagUnrecognized, resErr.FlagName))
 		}
-		if hydErr := friendlyHydrateError(resErr, "flag"); hydErr != nil {
+		if hydErr := cat.friendlyHydrateError(resErr, MsgFlag); hydErr != nil {
 			return hydErr
 		}
-		return fmt.Errorf("Cannot process flag %q (%v)", resErr.FlagName, resErr.Unwrap())
+		return errors.New(cat.Sprintf(MsgFlagCannotProcess, resErr.FlagName, resErr.Unwrap()))
 	}
 
 	if resErr := (*operandset.ResolveError)(nil); errors.As(err, &resErr) {
 		if errors.Is(resErr, operandset.ErrOperandRequired) {
-			return fmt.Errorf("Operand %q is required", resErr.OperandName)
+			return errors.New(cat.Sprintf(MsgOperandRequired, resErr.OperandName))
 		}
-		if hydErr := friendlyHydrateError(resErr, "operand"); hydErr != nil {
+		if hydErr := cat.friendlyHydrateError(resErr, MsgOperand); hydErr != nil {
 			return hydErr
 		}
-		return fmt.Errorf("Cannot process operand %q (%v)", resErr.OperandName, resErr.Unwrap())
+		return errors.New(cat.Sprintf(MsgOperandCannotProcess, resErr.OperandName, resErr.Unwrap()))
 	}
 
 	return err
 }
 
-func friendlyHydrateError(err error, typ string) error {
+func (cat Catalog) friendlyResponseFileError(err *ResponseFileError) error {
+	if errors.Is(err, ErrResponseFileDepth) {
+		return errors.New(cat.Sprintf(MsgResponseFileDepth, err.Path))
+	}
+
+	child := err.Unwrap()
+	if pathErr := (*fs.PathError)(nil); errors.As(child, &pathErr) {
+		child = pathErr.Err // path is already named
+	} else {
+		child = cat.UserFriendlyError(child)
+	}
+
+	if err.Line == 0 {
+		return errors.New(cat.Sprintf(MsgResponseFile, err.Path, child))
+	}
+	return errors.New(cat.Sprintf(MsgResponseFileLine, err.Line, err.Path, child))
+}
+
+func (cat Catalog) friendlyHydrateError(err error, typKey string) error {
 	if hydErr := (*vtypes.HydrateError)(nil); errors.As(err, &hydErr) {
+		typ := cat.Text(typKey)
 		if errors.Is(hydErr, vtypes.ErrTypeUnsupported) {
-			return fmt.Errorf("Unsupported %s value type '%T'", typ, hydErr.Val)
+			return errors.New(cat.Sprintf(MsgValueTypeUnsupported, typ, hydErr.Val))
 		}
-		return fmt.Errorf(
-			"Cannot set %s value of type '%T' (%v)",
-			typ, hydErr.Val, hydErr.Unwrap(),
-		)
+		return errors.New(cat.Sprintf(MsgValueCannotSet, typ, hydErr.Val, hydErr.Unwrap()))
 	}
 	return nil
 }
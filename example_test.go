@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/daved/clic"
 )
@@ -237,3 +239,219 @@ func Example_userFriendlyErrorMessages() {
 	//
 	// Unrecognized flag "force-err"
 }
+
+func Example_deprecation() {
+	// error handling omitted to keep example focused
+
+	var force bool
+
+	// Associate HandlerFuncs with command names, and deprecate the old ones
+	hello := clic.NewFromFunc(hello, "hello|hi")
+	hello.AliasDeprecations = map[string]*clic.Deprecation{
+		"hi": {Replacement: "hello"},
+	}
+	hello.Flag(&force, "force|f", "Force greeting.")
+	hello.DeprecateFlag("f", &clic.Deprecation{
+		Message:        "short form is ambiguous",
+		Replacement:    "--force",
+		RemovalVersion: "v2.0.0",
+	})
+
+	greet := clic.NewFromFunc(hello.Handler.HandleCommand, "greet")
+	greet.Deprecated = &clic.Deprecation{Replacement: "hello"}
+
+	root := clic.NewFromFunc(printRoot, "myapp", hello, greet)
+	root.WarningFunc = func(w *clic.Warning) {
+		fmt.Println("warning:", w)
+	}
+
+	// Parse the cli command as `myapp hi -f`, and run the handler
+	cmd, _ := root.Parse([]string{"hi", "-f"})
+	_ = cmd.Handle(context.Background())
+
+	fmt.Println()
+	fmt.Println(root.Usage())
+	fmt.Println(greet.Usage())
+	// Output:
+	// warning: alias "hi" is deprecated: use "hello" instead
+	// warning: flag "-f" is deprecated: short form is ambiguous; use "--force" instead; to be removed in v2.0.0
+	// Hello, World
+	//
+	// Usage:
+	//
+	//   myapp [hello]
+	//
+	// Usage:
+	//
+	//   myapp greet
+	//
+	//     Deprecated: use "hello" instead
+}
+
+func Example_localization() {
+	info := "none"
+
+	// Define catalogs for supported locales; missing keys fall back to English
+	catalogs := clic.Catalogs{
+		"de": {
+			clic.MsgUsage:            "Verwendung:",
+			clic.MsgFlagsFor:         "Optionen für %s:",
+			clic.MsgDefault:          "Standard: %s",
+			clic.MsgFlagUnrecognized: "Unbekannte Option %q",
+		},
+	}
+
+	// Associate HandlerFunc with command name, and set info flag
+	root := clic.NewFromFunc(printRoot, "myapp")
+	root.Flag(&info, "info", "Set info")
+
+	// Select a catalog explicitly (an empty locale is read from the environment)
+	cat := catalogs.Select("de_DE.UTF-8")
+	root.Recursively(func(c *clic.Clic) {
+		c.Catalog = cat
+	})
+
+	// Parse the cli command as `myapp --force-err`
+	cmd, err := root.Parse([]string{"--force-err"})
+	if err != nil {
+		fmt.Println(cmd.Usage())
+		fmt.Println(cmd.Catalog.UserFriendlyError(err))
+		return // likely as non-zero using os.Exit(n)
+	}
+	// Output:
+	// Verwendung:
+	//
+	//   myapp [FLAGS]
+	//
+	// Optionen für myapp:
+	//
+	//     --info  =STRING    Standard: none
+	//         Set info
+	//
+	// Unbekannte Option "force-err"
+}
+
+func Example_passthrough() {
+	// error handling omitted to keep example focused
+
+	var verbose bool
+
+	// Associate HandlerFunc with command name, and capture args verbatim
+	exec := clic.NewFromFunc(printRoot, "exec")
+	exec.Passthrough = true
+	exec.Flag(&verbose, "v", "Print the command before running it.")
+
+	root := clic.NewFromFunc(printRoot, "myapp", exec)
+
+	// Parse the cli command as `myapp exec -v -- kubectl get pods -n default`
+	cmd, _ := root.Parse([]string{"exec", "-v", "--", "kubectl", "get", "pods", "-n", "default"})
+
+	fmt.Printf("verbose: %v\nargs: %q\n", verbose, cmd.PassthroughArgs())
+	fmt.Println()
+	fmt.Println(cmd.Usage())
+	// Output:
+	// verbose: true
+	// args: ["kubectl" "get" "pods" "-n" "default"]
+	//
+	// Usage:
+	//
+	//   myapp exec [FLAGS] [-- ARGS...]
+	//
+	// Flags for exec:
+	//
+	//     -v  =BOOL    default: false
+	//         Print the command before running it.
+}
+
+func Example_invocationFromContext() {
+	// error handling omitted to keep example focused
+
+	var name string
+
+	// Associate HandlerFuncs with command names
+	hello := clic.NewFromFunc(hello, "hello")
+	hello.Operand(&name, false, "name", "Name to greet.")
+
+	root := clic.NewFromFunc(printRoot, "myapp", hello)
+
+	// Wrap all handlers with shared middleware that reads invocation details
+	root.Recursively(func(c *clic.Clic) {
+		next := c.Handler.HandleCommand
+		c.Handler = clic.HandlerFunc(func(ctx context.Context) error {
+			inv := clic.InvocationFrom(ctx)
+			fmt.Printf("path: %v, args: %v, operands: %v\n", inv.Path, inv.Args, inv.Operands)
+			return next(ctx)
+		})
+	})
+
+	// Parse the cli command as `myapp hello Alice`, and run the handler
+	cmd, _ := root.Parse([]string{"hello", "Alice"})
+	_ = cmd.Handle(context.Background())
+	// Output:
+	// path: [myapp hello], args: [hello Alice], operands: [Alice]
+	// Hello, World
+}
+
+func ExampleREPL() {
+	// Associate HandlerFuncs with command names
+	hello := clic.NewFromFunc(hello, "hello")
+	goodbye := clic.NewFromFunc(goodbye, "goodbye")
+
+	root := clic.NewFromFunc(printRoot, "myapp", hello, goodbye)
+	root.SubRequired = true
+	root.ResetOnParse = true
+
+	// Lines would typically be read from os.Stdin
+	in := strings.NewReader("hello\n!!\nhelo\n'unterminated\nexit\n")
+
+	_ = clic.REPL(context.Background(), root, in, os.Stdout, clic.REPLPrompt("> "))
+	// Output:
+	// > Hello, World
+	// > hello
+	// Hello, World
+	// > A subcommand is required
+	// > Unterminated quote or escape
+	// >
+}
+
+func Example_usageView() {
+	var verbose bool
+	var name string
+
+	// Associate HandlerFuncs with command names
+	hello := clic.NewFromFunc(hello, "hello")
+	hello.Description = "Say hello."
+	hello.Operand(&name, false, "name", "Name to greet.")
+
+	root := clic.NewFromFunc(printRoot, "myapp", hello)
+	root.Flag(&verbose, "verbose|v", "Print more details.")
+
+	// Set a custom template that relies on the view model and shared funcs
+	root.Recursively(func(c *clic.Clic) {
+		c.Tmpl = &clic.Tmpl{
+			Text: `{{StringsJoin (.Path | Names) " "}}: {{.Cmd.Description}}
+{{- range .Operands}}
+  {{Row 10 .Name .Description}}
+{{- end}}
+{{- range .InheritedFlags}}
+  {{Row 10 (index .Longs 0) (FlagDescription $.Cmd .)}}
+{{- end}}
+`,
+			FMap: clic.UsageFuncs(),
+			Data: clic.NewUsageView(c),
+		}
+		c.Tmpl.FMap["Names"] = func(cmds []*clic.Clic) []string {
+			var names []string
+			for _, cmd := range cmds {
+				names = append(names, cmd.FlagSet.Name())
+			}
+			return names
+		}
+	})
+
+	fmt.Print(hello.Usage())
+	// Output:
+	// myapp hello: Say hello.
+	//   name       Name to greet.
+	//   verbose    Print more details.
+}
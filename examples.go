@@ -0,0 +1,75 @@
+package clic
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Example describes an example invocation of a command.
+type Example struct {
+	Description string `json:"description,omitempty"`
+	Line        string `json:"line"` // full command line (e.g. "myapp add -v x")
+}
+
+// VerifyExamples parses the Line of each example of the Clic instance and all
+// its subcommands using the root command (see [Clic.ParseLine]), and reports
+// examples that fail to parse or that do not resolve to the command they
+// belong to (as [ErrExampleMismatch]). The first word of each line must be the
+// root command name. All problems found are returned (joined using
+// [errors.Join]).
+//
+// VerifyExamples is intended to be called in tests. Values are reset (see
+// [Clic.Reset]) before each example is parsed, and are left as parsed by the
+// last example. Prompting and warning callbacks of the root command are
+// disabled while verifying. Lazy placeholders (see [NewLazy]) are built.
+func (c *Clic) VerifyExamples() error {
+	root := cmdPath(nil, c)[0]
+
+	prompter, warnFn := root.Prompter, root.WarningFunc
+	root.Prompter, root.WarningFunc = nil, nil
+	defer func() {
+		root.Prompter, root.WarningFunc = prompter, warnFn
+	}()
+
+	var errs []error
+	c.verifyExamples(root, &errs)
+	return errors.Join(errs...)
+}
+
+func (c *Clic) verifyExamples(root *Clic, errs *[]error) {
+	c.materialize()
+
+	path := cmdNames(cmdPath(nil, c))
+	add := func(format string, args ...any) {
+		*errs = append(*errs, fmt.Errorf("%s: "+format, append([]any{path}, args...)...))
+	}
+
+	for _, ex := range c.Examples {
+		args, err := splitLine(ex.Line)
+		if err != nil {
+			add("example %q: %w", ex.Line, err)
+			continue
+		}
+
+		if len(args) == 0 || args[0] != root.FlagSet.Name() {
+			add("example %q: %w: must begin with %q", ex.Line, ErrExampleMismatch, root.FlagSet.Name())
+			continue
+		}
+
+		root.Reset()
+
+		resolved, err := root.Parse(args[1:])
+		if err != nil {
+			add("example %q: %w", ex.Line, err)
+			continue
+		}
+
+		if resolved != c {
+			add("example %q: %w: resolves to %q", ex.Line, ErrExampleMismatch, cmdNames(cmdPath(nil, resolved)))
+		}
+	}
+
+	for _, sub := range c.subs {
+		sub.verifyExamples(root, errs)
+	}
+}
@@ -0,0 +1,85 @@
+package clic
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newExamplesClic() (*Clic, *Clic) {
+	buf := &bytes.Buffer{}
+
+	sub := NewCmdClic(buf, "sub|s", nil)
+	sub.Examples = []Example{
+		{"Set info", "myapp sub --info=x"},
+		{"", "myapp s -n 2"},
+	}
+
+	root := NewCmdClic(buf, "myapp", nil, sub)
+	root.Examples = []Example{{"Show root", "myapp --num=1"}}
+
+	return root, sub
+}
+
+func TestClicVerifyExamples(t *testing.T) {
+	root, sub := newExamplesClic()
+	if err := root.VerifyExamples(); err != nil {
+		t.Fatal(err)
+	}
+
+	sub.Examples = append(sub.Examples,
+		Example{"Wrong command", "myapp --info=x"},
+		Example{"Unknown flag", "myapp sub --size=2"},
+		Example{"Wrong root", "other sub"},
+		Example{"Unterminated", "myapp sub 'x"},
+	)
+
+	err := root.VerifyExamples()
+	if !errors.Is(err, ErrExampleMismatch) || !errors.Is(err, CauseParseFlagUnrecognized) ||
+		!errors.Is(err, CauseParseLineUnterminated) {
+		t.Fatalf("got: %v", err)
+	}
+
+	if n := len(err.(interface{ Unwrap() []error }).Unwrap()); n != 4 {
+		t.Errorf("errors: got: %d, want: 4: %v", n, err)
+	}
+	if !strings.Contains(err.Error(), `myapp sub: example "myapp --info=x": example mismatch: resolves to "myapp"`) {
+		t.Errorf("got: %v", err)
+	}
+}
+
+func TestClicUsageExamples(t *testing.T) {
+	root, sub := newExamplesClic()
+
+	want := `
+Examples for sub:
+
+    myapp sub --info=x
+        Set info
+
+    myapp s -n 2
+`
+	if got := sub.Usage(); !strings.HasSuffix(got, want) {
+		t.Errorf("default: got:\n%s\nwant suffix:\n%s", got, want)
+	}
+
+	root.SetUsageStyle(NewGNUUsageTmpl)
+	want = `
+Examples:
+  myapp sub --info=x
+      Set info
+  myapp s -n 2
+`
+	if got := sub.Usage(); !strings.HasSuffix(got, want) {
+		t.Errorf("gnu: got:\n%s\nwant suffix:\n%s", got, want)
+	}
+
+	root.SetUsageStyle(NewCompactUsageTmpl)
+	want = `
+  $ myapp --num=1  # Show root
+`
+	if got := root.Usage(); !strings.HasSuffix(got, want) {
+		t.Errorf("compact: got:\n%s\nwant suffix:\n%s", got, want)
+	}
+}
@@ -0,0 +1,215 @@
+package clic
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/daved/flagset"
+	"github.com/daved/flagset/fserrs"
+)
+
+// flagUse describes a flag as it was provided to a parsed FlagSet.
+type flagUse struct {
+	flag *flagset.Flag
+	name string
+	raw  string
+}
+
+func (u flagUse) hint() string {
+	if utf8.RuneCountInString(u.name) == 1 {
+		return "-" + u.name
+	}
+	return "--" + u.name
+}
+
+// usedFlags reports the flags of the Clic instance that were set during the
+// most recent call to its FlagSet's Parse method (see scanFlagArg).
+func usedFlags(c *Clic) []flagUse {
+	fs := c.FlagSet
+	args := fs.Parsed()
+	args = args[:len(args)-len(fs.Operands())]
+
+	var uses []flagUse
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--" {
+			break
+		}
+		if !isFlagArg(args[i]) {
+			continue
+		}
+
+		next, _ := scanFlagArg(c, args[i], func(use flagUse) {
+			uses = append(uses, use)
+		})
+		if next && i+1 < len(args) {
+			i++
+			uses[len(uses)-1].raw = args[i]
+		}
+	}
+
+	return uses
+}
+
+// isFlagArg reports whether arg holds flags (i.e. it begins with a hyphen, and
+// is neither "-" nor "--").
+func isFlagArg(arg string) bool {
+	return len(arg) > 1 && arg[0] == '-' && arg != "--"
+}
+
+// scanFlagArg resolves the flags held by the flag arg (see isFlagArg), mirroring
+// the resolution rules of flagset (including the splitting of combined short
+// flags, where a flag that takes a value consumes the next short flag). Each
+// flag is passed to fn, if set, with its raw value when provided using "=" or
+// within combined short flags ("true" for bool flags). Next reports whether the
+// last flag consumes the following arg as its value, and ok is false if a flag
+// is unknown.
+func scanFlagArg(c *Clic, arg string, fn func(flagUse)) (next, ok bool) {
+	emit := func(use flagUse) {
+		if fn != nil {
+			fn(use)
+		}
+	}
+
+	if arg[1] == '-' {
+		name, raw, hasRaw := strings.Cut(arg[2:], "=")
+		flag := c.FlagSet.Lookup(name)
+		if flag == nil {
+			return false, false
+		}
+
+		next = !hasRaw && takesValue(c, flag)
+		if !hasRaw && !next {
+			raw = "true"
+		}
+		emit(flagUse{flag, name, raw})
+		return next, true
+	}
+
+	var use flagUse
+	for i := 1; i < len(arg); {
+		_, size := utf8.DecodeRuneInString(arg[i:])
+		name := arg[i : i+size]
+		i += size
+
+		if next {
+			use.raw, next = "-"+name, false // as split by flagset
+			continue
+		}
+		if use.flag != nil {
+			emit(use)
+		}
+
+		flag := c.FlagSet.Lookup(name)
+		if flag == nil {
+			return false, false
+		}
+
+		next = takesValue(c, flag)
+		use = flagUse{flag: flag, name: name}
+		if !next {
+			use.raw = "true"
+		}
+	}
+	emit(use)
+
+	return next, true
+}
+
+// takesValue reports whether the flag consumes the following argument as its
+// value when no value is provided using "=". As with flagset, flags with bool
+// values (i.e. *bool, or values with an IsBool method that returns true) do
+// not. The Clic instance and its parents are checked for the tracked value
+// (see [Clic.Flag]). The type name is used for untracked values.
+func takesValue(c *Clic, flag *flagset.Flag) bool {
+	for cmd := c; cmd != nil; cmd = cmd.parent {
+		if val, ok := cmd.flagVals[flag]; ok {
+			return !isBoolValue(val)
+		}
+	}
+	return flag.TypeName != "bool" && flag.TypeName != ""
+}
+
+func isBoolValue(val any) bool {
+	switch v := val.(type) {
+	case interface{ IsBool() bool }:
+		return v.IsBool()
+	case *bool, error:
+		return true
+	default:
+		return false
+	}
+}
+
+// leadingFlagsLen returns the number of leading args that hold flags of the
+// Clic instance (and their values). -1 is returned if the args must be resolved
+// in full to match flagset (e.g. there is no operand, or an unknown flag or
+// "--" precedes the first operand).
+func leadingFlagsLen(c *Clic, args []string) int {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		switch {
+		case arg == "" || arg == "--":
+			return -1
+
+		case !isFlagArg(arg):
+			return i
+
+		default:
+			next, ok := scanFlagArg(c, arg, nil)
+			if !ok {
+				return -1
+			}
+			if next {
+				i++
+			}
+		}
+	}
+
+	return -1
+}
+
+// intersperse reorders args so that flags provided after operands are moved
+// ahead of the operands. Args are returned unchanged if the first operand names
+// a subcommand, user-defined alias, or plugin. Arguments following "--" are
+// always treated as operands. ErrFlagValueMissing is returned if the last flag
+// requires a value that is not provided.
+func intersperse(c *Clic, args []string) ([]string, error) {
+	var flags, ops []string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		switch {
+		case arg == "--":
+			ops = append(ops, args[i+1:]...)
+			i = len(args)
+
+		case !isFlagArg(arg): // operand
+			if len(ops) == 0 && c.isSubCmdName(arg) {
+				return args, nil
+			}
+			ops = append(ops, arg)
+
+		default:
+			flags = append(flags, arg)
+
+			var name string
+			next, _ := scanFlagArg(c, arg, func(use flagUse) { name = use.name })
+			if !next {
+				continue
+			}
+			if i+1 >= len(args) {
+				return nil, fserrs.NewResolveError(ErrFlagValueMissing, name)
+			}
+			i++
+			flags = append(flags, args[i])
+		}
+	}
+
+	if len(ops) == 0 {
+		return args, nil
+	}
+
+	return append(append(flags, "--"), ops...), nil
+}
@@ -0,0 +1,59 @@
+package clic
+
+import (
+	"reflect"
+	"testing"
+)
+
+type toggle bool
+
+func (t *toggle) Set(string) error { *t = true; return nil }
+func (t *toggle) String() string   { return "" }
+func (t *toggle) IsBool() bool     { return true }
+
+func TestUsedFlags(t *testing.T) {
+	tt := []struct {
+		name string
+		args []string
+		want []string // name=raw
+	}{
+		{"none", []string{"opnd"}, nil},
+		{"long value", []string{"--info", "val", "opnd"}, []string{"info=val"}},
+		{"long equals", []string{"--info=val"}, []string{"info=val"}},
+		{"short bools", []string{"-vv", "-n", "3"}, []string{"v=true", "v=true", "n=3"}},
+		{"combined value", []string{"-vn", "3", "opnd"}, []string{"v=true", "n=3"}},
+		{"terminated", []string{"-v", "--", "--info"}, []string{"v=true"}},
+		{"flag-like value", []string{"-i", "-v"}, []string{"i=-v"}},
+		{"bool value type", []string{"--toggle", "opnd"}, []string{"toggle=true"}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				info string
+				num  int
+				verb bool
+				tog  toggle
+			)
+
+			c := New(nil, "test")
+			c.Flag(&info, "info|i", "")
+			c.Flag(&num, "num|n", "")
+			c.Flag(&verb, "v", "")
+			c.Flag(&tog, "toggle", "")
+
+			if err := c.FlagSet.Parse(tc.args); err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, use := range usedFlags(c) {
+				got = append(got, use.name+"="+use.raw)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got: %v, want: %v", got, tc.want)
+			}
+		})
+	}
+}
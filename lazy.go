@@ -0,0 +1,82 @@
+package clic
+
+import (
+	"maps"
+	"reflect"
+)
+
+// lazyKeptFields holds the exported fields that are always set by New, and so
+// cannot be copied by materialize based on whether they are set.
+var lazyKeptFields = map[string]bool{"FlagSet": true, "OperandSet": true, "Meta": true}
+
+// NewLazy returns a placeholder Clic instance that is built using fn only when
+// its details are needed (i.e. when Parse resolves to or through it, or when its
+// usage is requested). The name (including aliases), and fields such as
+// Description, Category, and HideUsage, should be set on the placeholder so
+// that parent usage output does not require building. The Clic instance
+// returned by fn should have the same name.
+//
+// When built, the returned instance's handler, flags, operands, subcommands,
+// and configuration are moved into the placeholder. Fields already set on the
+// placeholder take precedence, and the placeholder's Tmpl is retained. Calls to
+// [Clic.Recursively] that reach an unbuilt placeholder are deferred until it is
+// built, and SubCmds returns nothing until then (see [Clic.IsBuilt]).
+func NewLazy(name string, fn func() *Clic) *Clic {
+	c := New(nil, name)
+	c.build = fn
+	return c
+}
+
+// IsBuilt reports whether the Clic instance is built (i.e. it is not an unbuilt
+// placeholder returned by [NewLazy]).
+func (c *Clic) IsBuilt() bool {
+	return c.build == nil
+}
+
+// materialize builds the Clic instance if it is a lazy placeholder.
+func (c *Clic) materialize() {
+	if c.build == nil {
+		return
+	}
+
+	built := c.build()
+	c.build = nil
+
+	// Exported fields set on the placeholder take precedence.
+	cv, bv := reflect.ValueOf(c).Elem(), reflect.ValueOf(built).Elem()
+	for i := 0; i < cv.NumField(); i++ {
+		field := cv.Type().Field(i)
+		if !field.IsExported() || field.Anonymous || lazyKeptFields[field.Name] {
+			continue
+		}
+		if cv.Field(i).IsZero() {
+			cv.Field(i).Set(bv.Field(i))
+		}
+	}
+
+	c.FlagSet = built.FlagSet
+	c.OperandSet = built.OperandSet
+	for k, v := range built.Meta {
+		if _, ok := c.Meta[k]; !ok {
+			c.Meta[k] = v
+		}
+	}
+
+	c.restores = append(c.restores, built.restores...)
+	maps.Copy(c.flagVals, built.flagVals)
+	maps.Copy(c.opVals, built.opVals)
+	c.flagDeps = built.flagDeps
+	c.argAlias = built.argAlias
+
+	c.subs = built.subs
+	c.subIdx = nil
+	for _, sub := range c.subs {
+		sub.parent = c
+	}
+
+	pending := c.pending
+	c.pending = nil
+	for _, fn := range pending {
+		c.Recursively(fn)
+	}
+}
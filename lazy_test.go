@@ -0,0 +1,71 @@
+package clic
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNewLazy(t *testing.T) {
+	buf := &bytes.Buffer{}
+	var builds, wrapped int
+
+	lazy := NewLazy("lazy|lz", func() *Clic {
+		builds++
+		c := NewCmdClic(buf, "lazy", nil, NewCmdClic(buf, "leaf", nil))
+		c.Examples = []Example{{Line: "myapp lazy leaf"}}
+		c.Interspersed = true
+		return c
+	})
+	lazy.Description = "Built on demand"
+	lazy.Category = "Main"
+
+	root := NewCmdClic(buf, "myapp", nil, NewCmdClic(buf, "eager", nil), lazy)
+	root.SubCmdCatsSort = []string{"Main"}
+	root.Recursively(func(c *Clic) {
+		next := c.Handler.HandleCommand
+		c.Handler = HandlerFunc(func(ctx context.Context) error {
+			wrapped++
+			return next(ctx)
+		})
+	})
+
+	if _, err := root.Parse([]string{"eager"}); err != nil {
+		t.Fatal(err)
+	}
+	if usage := root.Usage(); !strings.Contains(usage, "Built on demand") {
+		t.Fatalf("usage: got: %s", usage)
+	}
+	if builds != 0 || lazy.IsBuilt() {
+		t.Fatalf("builds: got: %d, want: 0", builds)
+	}
+
+	cmd, err := root.Parse([]string{"lz", "--info=x", "leaf"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if builds != 1 || !lazy.IsBuilt() {
+		t.Fatalf("builds: got: %d, want: 1", builds)
+	}
+	if cmd.ParentCmd() != lazy || lazy.ParentCmd() != root {
+		t.Fatalf("links: unexpected parents")
+	}
+	if len(lazy.Examples) != 1 || !lazy.Interspersed || lazy.Description != "Built on demand" {
+		t.Fatalf("fields: got: %v %q, want: built examples and placeholder description", lazy.Examples, lazy.Description)
+	}
+	if err := lazy.SetFlag("info", "y", SourceEnv); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cmd.Handle(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "leaf"; got != want || wrapped != 1 {
+		t.Fatalf("handle: got: %q (wrapped %d), want: %q (wrapped 1)", got, wrapped, want)
+	}
+
+	if _, err := root.Parse([]string{"lazy", "leaf"}); err != nil || builds != 1 {
+		t.Fatalf("rebuild: got: %d %v, want: 1 nil", builds, err)
+	}
+}
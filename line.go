@@ -0,0 +1,89 @@
+package clic
+
+import (
+	"strings"
+
+	"github.com/daved/clic/cerrs"
+)
+
+// ParseLine splits line into args using POSIX shell quoting rules (single
+// quotes, double quotes, and backslash escapes), and then calls Parse. No
+// expansion (e.g. of variables or globs) is performed. Unterminated quotes and
+// escapes are reported as [CauseParseLineUnterminated].
+func (c *Clic) ParseLine(line string) (*Clic, error) {
+	args, err := splitLine(line)
+	if err != nil {
+		return c, cerrs.NewError(cerrs.NewParseError(err))
+	}
+
+	return c.Parse(args)
+}
+
+// splitLine tokenizes line using POSIX shell quoting rules (single quotes,
+// double quotes, and backslash escapes). No expansion is performed.
+func splitLine(line string) ([]string, error) {
+	var (
+		args    []string
+		cur     strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+			if r == '\n' { // line continuation
+				continue
+			}
+			if quote == '"' && !strings.ContainsRune("\\\"$`", r) {
+				cur.WriteRune('\\')
+			}
+			cur.WriteRune(r)
+
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+				continue
+			}
+			cur.WriteRune(r)
+
+		case r == '\\':
+			escaped = true
+			inArg = true
+
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+				continue
+			}
+			cur.WriteRune(r)
+
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+
+		default:
+			cur.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 || escaped {
+		return nil, ErrLineUnterminated
+	}
+
+	if inArg {
+		args = append(args, cur.String())
+	}
+
+	return args, nil
+}
@@ -0,0 +1,40 @@
+package clic
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSplitLine(t *testing.T) {
+	tt := []struct {
+		name string
+		line string
+		want []string
+		err  error
+	}{
+		{"empty", "  ", nil, nil},
+		{"plain", "a  b\tc", []string{"a", "b", "c"}, nil},
+		{"single quotes", `a 'b c' 'd\e'`, []string{"a", "b c", `d\e`}, nil},
+		{"double quotes", `"a b" "c\"d" "e\f" "$g"`, []string{"a b", `c"d`, `e\f`, "$g"}, nil},
+		{"escapes", `a\ b \'c\\`, []string{"a b", `'c\`}, nil},
+		{"empty quoted", `a "" ''`, []string{"a", "", ""}, nil},
+		{"adjacent", `--name="a b"c`, []string{"--name=a bc"}, nil},
+		{"continuation", "a \\\nb", []string{"a", "b"}, nil},
+		{"unterminated single", `a 'b`, nil, ErrLineUnterminated},
+		{"unterminated double", `a "b`, nil, ErrLineUnterminated},
+		{"trailing escape", `a \`, nil, ErrLineUnterminated},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := splitLine(tc.line)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("error: got: %v, want: %v", err, tc.err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got: %q, want: %q", got, tc.want)
+			}
+		})
+	}
+}
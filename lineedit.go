@@ -0,0 +1,223 @@
+package clic
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Key codes read by lineEditor.
+const (
+	keyCtrlA     = 1
+	keyCtrlB     = 2
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyCtrlF     = 6
+	keyCtrlH     = 8
+	keyTab       = 9
+	keyLF        = 10
+	keyCtrlK     = 11
+	keyCR        = 13
+	keyCtrlN     = 14
+	keyCtrlP     = 16
+	keyCtrlU     = 21
+	keyEsc       = 27
+	keyBackspace = 127
+	keyDelete    = -1 // escape sequence only
+)
+
+// lineEditor reads lines a key at a time from a terminal in raw mode (see
+// enableRaw). It supports cursor movement, history recall using the up and
+// down keys, and completion using the tab key.
+type lineEditor struct {
+	r        *bufio.Reader
+	out      io.Writer
+	complete func(before string) []string // candidates for the last word
+}
+
+// readLine reads a line after writing prompt. Hist holds previous lines, oldest
+// first. Pressing Ctrl-C discards the line. The error io.EOF is returned when
+// Ctrl-D is pressed on an empty line, or when input ends.
+func (e *lineEditor) readLine(prompt string, hist []string) (string, error) {
+	var buf, pending []rune
+	pos, histIdx := 0, len(hist)
+
+	redraw := func() {
+		fmt.Fprintf(e.out, "\r%s%s\x1b[K", prompt, string(buf))
+		if n := len(buf) - pos; n > 0 {
+			fmt.Fprintf(e.out, "\x1b[%dD", n)
+		}
+	}
+	recall := func(i int) {
+		if histIdx == len(hist) {
+			pending = slices.Clone(buf)
+		}
+		histIdx = i
+		buf = pending
+		if i < len(hist) {
+			buf = []rune(hist[i])
+		}
+		pos = len(buf)
+	}
+
+	fmt.Fprint(e.out, prompt)
+
+	for {
+		r, _, err := e.r.ReadRune()
+		if err != nil {
+			if err == io.EOF && len(buf) > 0 {
+				fmt.Fprint(e.out, "\n")
+				return string(buf), nil
+			}
+			return "", err
+		}
+
+		if r == keyEsc {
+			r = e.readEscape()
+		}
+
+		switch r {
+		case keyCR, keyLF:
+			fmt.Fprint(e.out, "\n")
+			return string(buf), nil
+
+		case keyCtrlC:
+			fmt.Fprint(e.out, "^C\n")
+			buf, pos, histIdx = nil, 0, len(hist)
+
+		case keyCtrlD, keyDelete:
+			if len(buf) == 0 && r == keyCtrlD {
+				fmt.Fprint(e.out, "\n")
+				return "", io.EOF
+			}
+			if pos < len(buf) {
+				buf = slices.Delete(buf, pos, pos+1)
+			}
+
+		case keyBackspace, keyCtrlH:
+			if pos > 0 {
+				buf = slices.Delete(buf, pos-1, pos)
+				pos--
+			}
+
+		case keyCtrlA:
+			pos = 0
+		case keyCtrlE:
+			pos = len(buf)
+		case keyCtrlB:
+			if pos > 0 {
+				pos--
+			}
+		case keyCtrlF:
+			if pos < len(buf) {
+				pos++
+			}
+		case keyCtrlK:
+			buf = buf[:pos]
+		case keyCtrlU:
+			buf, pos = slices.Clone(buf[pos:]), 0
+
+		case keyCtrlP:
+			if histIdx > 0 {
+				recall(histIdx - 1)
+			}
+		case keyCtrlN:
+			if histIdx < len(hist) {
+				recall(histIdx + 1)
+			}
+
+		case keyTab:
+			buf, pos = e.completeAt(buf, pos, prompt)
+
+		default:
+			if unicode.IsPrint(r) {
+				buf = slices.Insert(buf, pos, r)
+				pos++
+			}
+		}
+
+		redraw()
+	}
+}
+
+// readEscape reads the remainder of an escape sequence and returns the
+// equivalent control key, or zero if the sequence is not supported.
+func (e *lineEditor) readEscape() rune {
+	r, _, err := e.r.ReadRune()
+	if err != nil || (r != '[' && r != 'O') {
+		return 0
+	}
+
+	var seq []rune
+	for {
+		r, _, err := e.r.ReadRune()
+		if err != nil {
+			return 0
+		}
+		seq = append(seq, r)
+		if r >= '@' && r <= '~' { // final byte
+			break
+		}
+	}
+
+	switch string(seq) {
+	case "A":
+		return keyCtrlP
+	case "B":
+		return keyCtrlN
+	case "C":
+		return keyCtrlF
+	case "D":
+		return keyCtrlB
+	case "H", "1~", "7~":
+		return keyCtrlA
+	case "F", "4~", "8~":
+		return keyCtrlE
+	case "3~":
+		return keyDelete
+	default:
+		return 0
+	}
+}
+
+// completeAt completes the word before the cursor. A single candidate replaces
+// the word, multiple candidates extend it to their common prefix, and if the
+// word cannot be extended, the candidates are listed.
+func (e *lineEditor) completeAt(buf []rune, pos int, prompt string) ([]rune, int) {
+	before := string(buf[:pos])
+	cands := e.complete(before)
+	if len(cands) == 0 {
+		fmt.Fprint(e.out, "\a")
+		return buf, pos
+	}
+
+	word := before[strings.LastIndexAny(before, " \t")+1:]
+	ext := commonPrefix(cands)
+	if len(cands) == 1 {
+		ext += " "
+	}
+
+	if len(ext) > len(word) && strings.HasPrefix(ext, word) {
+		ins := []rune(ext[len(word):])
+		return slices.Insert(buf, pos, ins...), pos + len(ins)
+	}
+
+	if len(cands) > 1 {
+		fmt.Fprintf(e.out, "\n%s\n%s", strings.Join(cands, "  "), prompt)
+	}
+	return buf, pos
+}
+
+func commonPrefix(ss []string) string {
+	prefix := ss[0]
+	for _, s := range ss[1:] {
+		for !strings.HasPrefix(s, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
@@ -0,0 +1,58 @@
+package clic
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLineEditorReadLine(t *testing.T) {
+	status := New(nil, "status")
+	stop := New(nil, "stop")
+	root := New(nil, "myapp", status, stop)
+
+	hist := []string{"first", "second"}
+
+	tt := []struct {
+		name string
+		keys string
+		want string
+		err  error
+	}{
+		{"plain", "status\r", "status", nil},
+		{"backspace", "stopx\x7f\r", "stop", nil},
+		{"insert after left", "sop\x1b[D\x1b[Dt\r", "stop", nil},
+		{"home and end", "top\x01s\x05!\r", "stop!", nil},
+		{"delete key", "sxtop\x01\x1b[C\x1b[3~\r", "stop", nil},
+		{"kill to end", "stop now\x1b[D\x1b[D\x1b[D\x1b[D\x0b\r", "stop", nil},
+		{"history up", "\x1b[A\r", "second", nil},
+		{"history up twice", "\x1b[A\x1b[A\r", "first", nil},
+		{"history down to pending", "sta\x1b[A\x1b[B\r", "sta", nil},
+		{"complete single", "sta\t\r", "status ", nil},
+		{"complete common prefix", "s\t\r", "st", nil},
+		{"complete none", "x\t\r", "x", nil},
+		{"interrupt", "junk\x03ok\r", "ok", nil},
+		{"eof on empty", "\x04", "", io.EOF},
+		{"eof after text", "stop", "stop", nil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ed := &lineEditor{
+				r:        bufio.NewReader(strings.NewReader(tc.keys)),
+				out:      io.Discard,
+				complete: func(before string) []string { return replComplete(root, before) },
+			}
+
+			got, err := ed.readLine("> ", hist)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("error: got: %v, want: %v", err, tc.err)
+			}
+			if got != tc.want {
+				t.Fatalf("got: %q, want: %q", got, tc.want)
+			}
+		})
+	}
+}
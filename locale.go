@@ -0,0 +1,144 @@
+package clic
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+)
+
+// Message keys are used to look up user-facing text in a [Catalog]. See
+// [DefaultCatalog] for the default (English) text and formatting verbs.
+const (
+	MsgUsage                = "usage"
+	MsgFlagsFor             = "flags-for"
+	MsgAliasesFor           = "aliases-for"
+	MsgSubcommandsFor       = "subcommands-for"
+	MsgDefault              = "default"
+	MsgDeprecated           = "deprecated"
+	MsgOptions              = "options"
+	MsgOptionsFor           = "options-for"
+	MsgArguments            = "arguments"
+	MsgCommands             = "commands"
+	MsgExamples             = "examples"
+	MsgExamplesFor          = "examples-for"
+	MsgFlag                 = "flag"
+	MsgOperand              = "operand"
+	MsgCommand              = "command"
+	MsgAlias                = "alias"
+	MsgPlugins              = "plugins"
+	MsgUseInstead           = "use-instead"
+	MsgRemovalVersion       = "removal-version"
+	MsgIsDeprecated         = "is-deprecated"
+	MsgPromptLabel          = "prompt-label"
+	MsgHistoryNotFound      = "history-not-found"
+	MsgSubCmdRequired       = "subcmd-required"
+	MsgLineUnterminated     = "line-unterminated"
+	MsgAliasRecursive       = "alias-recursive"
+	MsgAliasUnresolved      = "alias-unresolved"
+	MsgResponseFile         = "response-file"
+	MsgResponseFileLine     = "response-file-line"
+	MsgResponseFileDepth    = "response-file-depth"
+	MsgFlagUnrecognized     = "flag-unrecognized"
+	MsgFlagCannotProcess    = "flag-cannot-process"
+	MsgOperandRequired      = "operand-required"
+	MsgOperandCannotProcess = "operand-cannot-process"
+	MsgValueTypeUnsupported = "value-type-unsupported"
+	MsgValueCannotSet       = "value-cannot-set"
+)
+
+var defaultCatalog = Catalog{
+	MsgUsage:                "Usage:",
+	MsgFlagsFor:             "Flags for %s:",
+	MsgAliasesFor:           "Aliases for %s:",
+	MsgSubcommandsFor:       "Subcommands for %s:",
+	MsgDefault:              "default: %s",
+	MsgDeprecated:           "Deprecated",
+	MsgOptions:              "Options:",
+	MsgOptionsFor:           "Options for %s:",
+	MsgArguments:            "Arguments:",
+	MsgCommands:             "Commands:",
+	MsgExamples:             "Examples:",
+	MsgExamplesFor:          "Examples for %s:",
+	MsgFlag:                 "flag",
+	MsgOperand:              "operand",
+	MsgCommand:              "command",
+	MsgAlias:                "alias",
+	MsgPlugins:              "Plugins",
+	MsgUseInstead:           "use %q instead",
+	MsgRemovalVersion:       "to be removed in %s",
+	MsgIsDeprecated:         "%s %q is deprecated",
+	MsgPromptLabel:          "%s (%s)",
+	MsgHistoryNotFound:      "No history entry for %q",
+	MsgSubCmdRequired:       "A subcommand is required",
+	MsgLineUnterminated:     "Unterminated quote or escape",
+	MsgAliasRecursive:       "Alias %q expands to itself (%s)",
+	MsgAliasUnresolved:      "Alias %q expands to unknown command %q",
+	MsgResponseFile:         "Cannot read response file %q (%v)",
+	MsgResponseFileLine:     "Cannot read line %d of response file %q (%v)",
+	MsgResponseFileDepth:    "Response file %q is nested too deeply",
+	MsgFlagUnrecognized:     "Unrecognized flag %q",
+	MsgFlagCannotProcess:    "Cannot process flag %q (%v)",
+	MsgOperandRequired:      "Operand %q is required",
+	MsgOperandCannotProcess: "Cannot process operand %q (%v)",
+	MsgValueTypeUnsupported: "Unsupported %s value type '%T'",
+	MsgValueCannotSet:       "Cannot set %s value of type '%T' (%v)",
+}
+
+// Catalog maps message keys to user-facing text. Missing keys fall back to the
+// default (English) text, so a nil Catalog is valid and produces English.
+type Catalog map[string]string
+
+// DefaultCatalog returns a copy of the default (English) catalog. It can be
+// used as a reference, or as a starting point for a new catalog.
+func DefaultCatalog() Catalog {
+	return maps.Clone(defaultCatalog)
+}
+
+// Text returns the text associated with key.
+func (cat Catalog) Text(key string) string {
+	if s, ok := cat[key]; ok {
+		return s
+	}
+	return defaultCatalog[key]
+}
+
+// Sprintf formats the text associated with key using the provided args.
+func (cat Catalog) Sprintf(key string, args ...any) string {
+	return fmt.Sprintf(cat.Text(key), args...)
+}
+
+// Catalogs maps locales (e.g. "de", "ja_JP") to catalogs.
+type Catalogs map[string]Catalog
+
+// Select returns the catalog that best matches the provided locale. If locale
+// is empty, it is determined by [EnvLocale]. Locales are matched on the full
+// language and territory (e.g. "de_DE"), and then on the language alone. A nil
+// catalog (i.e. the default) is returned when there is no match.
+func (cs Catalogs) Select(locale string) Catalog {
+	if locale == "" {
+		locale = EnvLocale()
+	}
+
+	locale, _, _ = strings.Cut(locale, ".") // drop encoding
+	locale, _, _ = strings.Cut(locale, "@") // drop modifier
+	locale = strings.ReplaceAll(locale, "-", "_")
+
+	if cat, ok := cs[locale]; ok {
+		return cat
+	}
+
+	lang, _, _ := strings.Cut(locale, "_")
+	return cs[lang]
+}
+
+// EnvLocale returns the messages locale set in the environment, checking
+// LC_ALL, LC_MESSAGES, and LANG in that order.
+func EnvLocale() string {
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(key); v != "" {
+			return v
+		}
+	}
+	return ""
+}
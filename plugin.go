@@ -0,0 +1,154 @@
+package clic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// PluginsCategory is the category set on plugin commands. It is displayed
+// using the MsgPlugins text of the command's catalog.
+const PluginsCategory = "Plugins"
+
+// Plugins configures dispatch of unmatched subcommand names to external
+// executables (e.g. "myapp foo" runs "myapp-foo"). On Windows, executables are
+// files with an extension listed in PATHEXT (e.g. "myapp-foo.exe").
+type Plugins struct {
+	Pattern string   // executable name pattern; defaults to "<command name>-%s"
+	Dirs    []string // searched in order before PATH
+	NoPath  bool     // do not search PATH
+}
+
+// PluginExitError signals that a plugin exited with a non-zero code.
+type PluginExitError struct {
+	Name string
+	Code int
+}
+
+// Error implements the error interface.
+func (e *PluginExitError) Error() string {
+	return fmt.Sprintf("plugin %s: exit code %d", e.Name, e.Code)
+}
+
+// ExitCode returns the exit code of the plugin.
+func (e *PluginExitError) ExitCode() int {
+	return e.Code
+}
+
+func (p *Plugins) affixes(c *Clic) (string, string) {
+	pattern := p.Pattern
+	if pattern == "" {
+		pattern = c.FlagSet.Name() + "-%s"
+	}
+	pre, suf, _ := strings.Cut(pattern, "%s")
+	return pre, suf
+}
+
+func (p *Plugins) dirs() []string {
+	dirs := slices.Clone(p.Dirs)
+	if !p.NoPath {
+		dirs = append(dirs, filepath.SplitList(os.Getenv("PATH"))...)
+	}
+	return dirs
+}
+
+// lookup returns the path of the executable for the named plugin.
+func (p *Plugins) lookup(c *Clic, name string) string {
+	if name == "" || strings.ContainsAny(name, "/"+string(filepath.Separator)) {
+		return ""
+	}
+
+	pre, suf := p.affixes(c)
+	for _, dir := range p.dirs() {
+		for _, ext := range executableExts() {
+			path := filepath.Join(dir, pre+name+suf+ext)
+			if isExecutable(path) {
+				return path
+			}
+		}
+	}
+	return ""
+}
+
+// PluginCmds returns a Clic instance for each plugin found using the Plugins
+// field. Plugins that share a name with a subcommand are not included.
+func (c *Clic) PluginCmds() []*Clic {
+	if c.Plugins == nil {
+		return nil
+	}
+
+	pre, suf := c.Plugins.affixes(c)
+	var names []string
+
+	for _, dir := range c.Plugins.dirs() {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			file := entry.Name()
+			stem := trimExecutableExt(file)
+			if len(stem) <= len(pre)+len(suf) || !strings.HasPrefix(stem, pre) || !strings.HasSuffix(stem, suf) {
+				continue
+			}
+
+			name := stem[len(pre) : len(stem)-len(suf)]
+			if slices.Contains(names, name) || c.lookupSub(name) != nil {
+				continue
+			}
+			if isExecutable(filepath.Join(dir, file)) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	var cmds []*Clic
+	for _, name := range names {
+		cmds = append(cmds, c.newPluginCmd(name, ""))
+	}
+	return cmds
+}
+
+// newPluginCmd returns a Clic instance that runs the plugin executable located
+// at path, passing along its passthrough args and the streams provided by
+// [StdioFrom].
+func (c *Clic) newPluginCmd(name, path string) *Clic {
+	var plugin *Clic
+
+	run := func(ctx context.Context) error {
+		cmd := exec.CommandContext(ctx, path, plugin.passArgs...)
+		stdio := StdioFrom(ctx)
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = stdio.In, stdio.Out, stdio.Err
+
+		err := cmd.Run()
+		if exitErr := (*exec.ExitError)(nil); errors.As(err, &exitErr) {
+			return &PluginExitError{name, exitErr.ExitCode()}
+		}
+		return err
+	}
+
+	plugin = NewFromFunc(run, name)
+	plugin.parent = c
+	plugin.Category = PluginsCategory
+	plugin.Passthrough = true
+	plugin.Catalog = c.Catalog
+
+	return plugin
+}
+
+// trimExecutableExt returns file without its executable extension (if any).
+func trimExecutableExt(file string) string {
+	ext := filepath.Ext(file)
+	for _, e := range executableExts() {
+		if e != "" && strings.EqualFold(ext, e) {
+			return file[:len(file)-len(ext)]
+		}
+	}
+	return file
+}
@@ -0,0 +1,15 @@
+//go:build !windows
+
+package clic
+
+import "os"
+
+// executableExts returns the file extensions tried when looking up plugins.
+func executableExts() []string {
+	return []string{""}
+}
+
+func isExecutable(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && !fi.IsDir() && fi.Mode()&0o111 != 0
+}
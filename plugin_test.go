@@ -0,0 +1,60 @@
+package clic
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestClicPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin test uses a shell script (see plugin_windows_test.go)")
+	}
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	script := "#!/bin/sh\necho \"$@\" > " + out + "\nexit 3\n"
+	if err := os.WriteFile(filepath.Join(dir, "myapp-hello"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "myapp-noexec"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	root := New(nil, "myapp", New(nil, "builtin"))
+	root.Plugins = &Plugins{Dirs: []string{dir}, NoPath: true}
+
+	cmd, err := root.Parse([]string{"hello", "--name", "x", "y"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd.ParentCmd() != root || cmd.FlagSet.Name() != "hello" {
+		t.Fatalf("resolved: got: %v, want: hello", cmd.FlagSet.Name())
+	}
+
+	err = cmd.Handle(context.Background())
+	if exitErr := (*PluginExitError)(nil); !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+		t.Fatalf("handle: got: %v, want: exit code 3", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.TrimSpace(string(data)), "--name x y"; got != want {
+		t.Fatalf("args: got: %q, want: %q", got, want)
+	}
+
+	if _, err := root.Parse([]string{"noexec"}); err != nil {
+		t.Fatal(err) // resolves to root with operand
+	}
+
+	usage := root.Usage()
+	if !strings.Contains(usage, "  Plugins ") || !strings.Contains(usage, "    hello ") || strings.Contains(usage, "noexec") {
+		t.Fatalf("usage: got: %s", usage)
+	}
+}
@@ -0,0 +1,33 @@
+package clic
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// executableExts returns the file extensions tried when looking up plugins,
+// as listed in PATHEXT.
+func executableExts() []string {
+	pathext := os.Getenv("PATHEXT")
+	if pathext == "" {
+		pathext = ".com;.exe;.bat;.cmd"
+	}
+
+	var exts []string
+	for _, ext := range strings.Split(strings.ToLower(pathext), ";") {
+		if ext != "" {
+			exts = append(exts, ext)
+		}
+	}
+	return exts
+}
+
+func isExecutable(path string) bool {
+	fi, err := os.Stat(path)
+	if err != nil || fi.IsDir() {
+		return false
+	}
+	return slices.Contains(executableExts(), strings.ToLower(filepath.Ext(path)))
+}
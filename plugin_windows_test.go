@@ -0,0 +1,34 @@
+package clic
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestClicPluginsWindows(t *testing.T) {
+	t.Setenv("PATHEXT", ".COM;.EXE;.BAT")
+
+	dir := t.TempDir()
+	for _, file := range []string{"myapp-hello.bat", "myapp-notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte("@exit /b 3\r\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	root := New(nil, "myapp")
+	root.Plugins = &Plugins{Dirs: []string{dir}, NoPath: true}
+
+	want := filepath.Join(dir, "myapp-hello.bat")
+	if got := root.Plugins.lookup(root, "hello"); got != want {
+		t.Fatalf("lookup: got: %q, want: %q", got, want)
+	}
+	if got := root.Plugins.lookup(root, "notes"); got != "" {
+		t.Fatalf("lookup non-executable: got: %q, want: none", got)
+	}
+
+	cmds := root.PluginCmds()
+	if len(cmds) != 1 || cmds[0].FlagSet.Name() != "hello" {
+		t.Fatalf("plugin cmds: got: %v, want: [hello]", cmds)
+	}
+}
@@ -0,0 +1,140 @@
+package clic
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/daved/operandset"
+)
+
+// ErrPromptUnavailable signals that a [Prompter] cannot prompt (e.g. because
+// input is not a terminal). Parse returns the original error in that case.
+var ErrPromptUnavailable = errors.New("prompt unavailable")
+
+const maxPromptTries = 3
+
+// PromptRequest describes a value being prompted for.
+type PromptRequest struct {
+	Cmd         *Clic
+	Name        string
+	Description string
+	Secret      bool  // input should be masked
+	Err         error // set when the previously entered value was invalid
+}
+
+// Prompter describes types that can prompt for missing values.
+type Prompter interface {
+	Prompt(*PromptRequest) (string, error)
+}
+
+// parseOperands parses args as the operands of the Clic instance. When p is
+// not nil, missing required operands are prompted for. Entered values are
+// validated by the normal operand resolution, and invalid values are prompted
+// for again (up to a small limit).
+func (c *Clic) parseOperands(p Prompter, args []string) error {
+	provided := len(args)
+
+	err := c.OperandSet.Parse(args)
+	tries := 0
+
+	for err != nil && p != nil {
+		resErr := (*operandset.ResolveError)(nil)
+		if !errors.As(err, &resErr) {
+			return err
+		}
+
+		ops := c.OperandSet.Operands()
+		i := slices.IndexFunc(ops, func(op *operandset.Operand) bool {
+			return op.Name() == resErr.OperandName
+		})
+
+		req := &PromptRequest{Cmd: c}
+		switch {
+		case i < 0 || i < provided:
+			return err
+
+		case i < len(args): // prompted value did not resolve
+			tries++
+			if tries >= maxPromptTries {
+				return err
+			}
+			args = args[:i]
+			req.Err = err
+		}
+
+		op := ops[i]
+		req.Name = op.Name()
+		req.Description = op.Description()
+		req.Secret = slices.Contains(c.Secrets, op.Name())
+
+		raw, perr := p.Prompt(req)
+		if perr != nil {
+			if errors.Is(perr, ErrPromptUnavailable) {
+				return err
+			}
+			return perr
+		}
+
+		args = append(slices.Clip(args), raw)
+		err = c.OperandSet.Parse(args)
+	}
+
+	return err
+}
+
+// TermPrompter is a [Prompter] that reads from a terminal. Secret values are
+// read with echo disabled on supported platforms.
+type TermPrompter struct {
+	in  *os.File
+	out io.Writer
+	r   *bufio.Reader
+}
+
+// NewTermPrompter returns an instance of TermPrompter. Typical usage would
+// provide [os.Stdin] and [os.Stderr].
+func NewTermPrompter(in *os.File, out io.Writer) *TermPrompter {
+	return &TermPrompter{
+		in:  in,
+		out: out,
+		r:   bufio.NewReader(in),
+	}
+}
+
+// Prompt implements [Prompter]. ErrPromptUnavailable is returned if input is
+// not a terminal, or if echo cannot be disabled for a secret value.
+func (p *TermPrompter) Prompt(req *PromptRequest) (string, error) {
+	if !isTerminal(p.in) {
+		return "", ErrPromptUnavailable
+	}
+
+	if req.Err != nil {
+		fmt.Fprintln(p.out, req.Cmd.Catalog.UserFriendlyError(req.Err))
+	}
+
+	label := req.Name
+	if req.Description != "" {
+		label = req.Cmd.Catalog.Sprintf(MsgPromptLabel, req.Description, req.Name)
+	}
+	fmt.Fprintf(p.out, "%s: ", label)
+
+	if req.Secret {
+		restore, err := disableEcho(p.in)
+		if err != nil {
+			return "", ErrPromptUnavailable
+		}
+		defer fmt.Fprintln(p.out)
+		defer restore()
+	}
+
+	line, err := p.r.ReadString('\n')
+	if err != nil && (line == "" || !errors.Is(err, io.EOF)) {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
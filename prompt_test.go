@@ -0,0 +1,79 @@
+package clic
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type scriptedPrompter struct {
+	answers []string
+	reqs    []PromptRequest
+}
+
+func (p *scriptedPrompter) Prompt(req *PromptRequest) (string, error) {
+	p.reqs = append(p.reqs, *req)
+	if len(p.answers) == 0 {
+		return "", ErrPromptUnavailable
+	}
+	answer := p.answers[0]
+	p.answers = p.answers[1:]
+	return answer, nil
+}
+
+func TestClicParsePrompt(t *testing.T) {
+	tt := []struct {
+		name    string
+		args    []string
+		answers []string
+		user    string
+		port    int
+		prompts []string
+		cause   error
+	}{
+		{"provided", []string{"bob", "22"}, nil, "bob", 22, nil, nil},
+		{"one missing", []string{"bob"}, []string{"22"}, "bob", 22, []string{"port"}, nil},
+		{"both missing", nil, []string{"bob", "22"}, "bob", 22, []string{"user", "port"}, nil},
+		{"retry invalid", nil, []string{"bob", "x", "22"}, "bob", 22, []string{"user", "port", "port"}, nil},
+		{"too many invalid", nil, []string{"bob", "x", "y", "z"}, "", 0, []string{"user", "port", "port", "port"}, CauseParseHydrateError},
+		{"unavailable", nil, nil, "", 0, []string{"user"}, CauseParseOperandRequired},
+		{"provided invalid", []string{"bob", "x"}, []string{"22"}, "", 0, nil, CauseParseHydrateError},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				user string
+				port int
+			)
+
+			root := New(nil, "myapp")
+			root.Operand(&user, true, "user", "User name")
+			root.Operand(&port, true, "port", "Port number")
+			root.Secrets = []string{"user"}
+
+			p := &scriptedPrompter{answers: tc.answers}
+			root.Prompter = p
+
+			_, err := root.Parse(tc.args)
+			if !errors.Is(err, tc.cause) {
+				t.Fatalf("error: got: %v, want: %v", err, tc.cause)
+			}
+
+			var prompts []string
+			for _, req := range p.reqs {
+				prompts = append(prompts, req.Name)
+				if req.Secret != (req.Name == "user") {
+					t.Fatalf("secret: got: %v for %s", req.Secret, req.Name)
+				}
+			}
+			if !reflect.DeepEqual(prompts, tc.prompts) {
+				t.Fatalf("prompts: got: %v, want: %v", prompts, tc.prompts)
+			}
+
+			if err == nil && (user != tc.user || port != tc.port) {
+				t.Fatalf("vals: got: %v %v, want: %v %v", user, port, tc.user, tc.port)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package clic
+
+import (
+	"github.com/daved/clic/cerrs"
+	"github.com/daved/flagset"
+	"github.com/daved/flagset/fserrs"
+	"github.com/daved/vtypes"
+)
+
+// Source describes where a flag value came from.
+type Source int
+
+// Source values.
+const (
+	SourceDefault Source = iota
+	SourceCLI
+	SourceEnv
+	SourceFile
+)
+
+// String implements [fmt.Stringer].
+func (s Source) String() string {
+	switch s {
+	case SourceCLI:
+		return "cli"
+	case SourceEnv:
+		return "env"
+	case SourceFile:
+		return "file"
+	default:
+		return "default"
+	}
+}
+
+// Provenance holds the source of a flag value and the raw text it was set from.
+// Raw is empty for default values.
+type Provenance struct {
+	Source Source
+	Raw    string
+}
+
+// FlagSource returns the provenance of the flag with the provided name (long or
+// short). Flags of parent commands are checked if the Clic instance does not
+// have a matching flag. Flags set on the command line are derived from the
+// args of the most recent call to Parse (the last occurrence wins), unless the
+// flags of the owning command could not be parsed. Flags set by [Clic.SetFlag]
+// take precedence.
+func (c *Clic) FlagSource(name string) Provenance {
+	owner, flag := c.lookupFlag(name)
+	if flag == nil {
+		return Provenance{}
+	}
+
+	if p, ok := owner.flagSrcs[flag]; ok || !owner.argSrcs {
+		return p
+	}
+
+	var p Provenance
+	for _, use := range usedFlags(owner) {
+		if use.flag == flag {
+			p = Provenance{SourceCLI, use.raw}
+		}
+	}
+	return p
+}
+
+// SetFlag hydrates the flag with the provided name (long or short) using raw,
+// and records src as its provenance. Flags of parent commands are checked if
+// the Clic instance does not have a matching flag. The flag must have been
+// added using [Clic.Flag].
+//
+// SetFlag is intended to be called after Parse in order to merge values from
+// other sources (e.g. environment variables or config files) with those from
+// the command line. Use [Clic.FlagSource] to skip flags that were set on the
+// command line.
+func (c *Clic) SetFlag(name, raw string, src Source) error {
+	wrap := func(err error) error {
+		return cerrs.NewError(fserrs.NewResolveError(err, name))
+	}
+
+	owner, flag := c.lookupFlag(name)
+	if flag == nil {
+		return wrap(flagset.ErrFlagUnrecognized)
+	}
+
+	val, ok := owner.flagVals[flag]
+	if !ok {
+		return wrap(ErrValueUntracked)
+	}
+
+	if err := vtypes.Hydrate(val, raw); err != nil {
+		return wrap(err)
+	}
+
+	owner.setFlagSource(flag, Provenance{src, raw})
+	return nil
+}
+
+func (c *Clic) lookupFlag(name string) (*Clic, *flagset.Flag) {
+	for cmd := c; cmd != nil; cmd = cmd.parent {
+		if flag := cmd.FlagSet.Lookup(name); flag != nil {
+			return cmd, flag
+		}
+	}
+	return nil, nil
+}
+
+func (c *Clic) setFlagSource(flag *flagset.Flag, p Provenance) {
+	if c.flagSrcs == nil {
+		c.flagSrcs = make(map[*flagset.Flag]Provenance)
+	}
+	c.flagSrcs[flag] = p
+}
+
+// clearFlagSources drops the recorded flag provenance of each command in the
+// parsed command path so that it is derived from the parsed args instead.
+func clearFlagSources(path []*Clic) {
+	for _, cmd := range path {
+		cmd.flagSrcs = nil
+		cmd.argSrcs = true
+	}
+}
@@ -0,0 +1,74 @@
+package clic
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClicFlagSource(t *testing.T) {
+	var (
+		verbose bool
+		timeout = time.Second
+		region  = "us"
+		untrack string
+	)
+
+	sub := New(nil, "subcmd")
+	sub.Flag(&timeout, "timeout|t", "")
+	sub.FlagSet.Flag(&untrack, "untracked", "")
+
+	root := New(nil, "myapp", sub)
+	root.Flag(&verbose, "verbose|v", "")
+	root.Flag(&region, "region", "")
+
+	cmd, err := root.Parse([]string{"-v", "subcmd", "--timeout", "3s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cmd.SetFlag("region", "eu", SourceEnv); err != nil {
+		t.Fatal(err)
+	}
+
+	tt := []struct {
+		name string
+		want Provenance
+	}{
+		{"v", Provenance{SourceCLI, "true"}},
+		{"verbose", Provenance{SourceCLI, "true"}},
+		{"t", Provenance{SourceCLI, "3s"}},
+		{"region", Provenance{SourceEnv, "eu"}},
+		{"untracked", Provenance{SourceDefault, ""}},
+		{"unknown", Provenance{SourceDefault, ""}},
+	}
+
+	for _, tc := range tt {
+		if got := cmd.FlagSource(tc.name); got != tc.want {
+			t.Errorf("%s: got: %v, want: %v", tc.name, got, tc.want)
+		}
+	}
+
+	if timeout != 3*time.Second || region != "eu" {
+		t.Fatalf("vals: got: %v %v, want: 3s eu", timeout, region)
+	}
+
+	if err := cmd.SetFlag("untracked", "x", SourceFile); !errors.Is(err, ErrValueUntracked) {
+		t.Fatalf("untracked: got: %v, want: %v", err, ErrValueUntracked)
+	}
+
+	err = cmd.SetFlag("timeout", "soon", SourceFile)
+	if !errors.Is(err, CauseParseFlagResolve) || !errors.Is(err, CauseParseHydrateError) {
+		t.Fatalf("hydrate: got: %v, want: %v", err, CauseParseHydrateError)
+	}
+
+	if _, err := root.Parse([]string{"subcmd", "--timeout", "soon"}); err == nil {
+		t.Fatal("failed parse: got: nil error")
+	}
+
+	for _, name := range []string{"verbose", "timeout", "region"} {
+		if got := cmd.FlagSource(name); got != (Provenance{}) {
+			t.Errorf("failed parse: %s: got: %v, want: %v", name, got, Provenance{})
+		}
+	}
+}
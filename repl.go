@@ -0,0 +1,229 @@
+package clic
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/daved/clic/cerrs"
+)
+
+// REPLOption configures [REPL].
+type REPLOption func(*replConfig)
+
+type replConfig struct {
+	prompt  string
+	histMax int
+}
+
+// REPLPrompt sets the prompt text. The default is the root command name
+// followed by "> ".
+func REPLPrompt(prompt string) REPLOption {
+	return func(cnf *replConfig) {
+		cnf.prompt = prompt
+	}
+}
+
+// REPLHistorySize sets the maximum number of lines kept in history. The default
+// is 500.
+func REPLHistorySize(n int) REPLOption {
+	return func(cnf *replConfig) {
+		cnf.histMax = n
+	}
+}
+
+// REPL runs an interactive loop that reads lines from in, parses them against
+// the root command tree (without the root command name), and handles the
+// resolved commands. Errors are written to out as [Catalog.UserFriendlyError]
+// messages (using the root's catalog) and do not end the loop. REPL returns
+// when in is exhausted, ctx is done, or "exit" or "quit" is entered.
+//
+// The following builtin commands are available unless shadowed by a
+// subcommand of root:
+//
+//	help [command...]   print usage for the root or the named command
+//	history             print previously entered lines
+//	!!, !N              run the previous line, or line N of history
+//	exit, quit          end the loop
+//
+// When in is a terminal (on supported platforms), lines are edited a key at a
+// time: the tab key completes subcommand and flag names (see [Clic.Complete]),
+// the up and down keys recall history, and the usual cursor movement and
+// deletion keys (including Ctrl-A, Ctrl-E, Ctrl-K, and Ctrl-U) are available.
+// Ctrl-C discards the current line, and Ctrl-D on an empty line ends the loop.
+// Otherwise, lines are read as-is.
+//
+// Usage is printed for resolved commands without a handler. Setting
+// root.ResetOnParse is recommended so that values do not leak between lines.
+func REPL(ctx context.Context, root *Clic, in io.Reader, out io.Writer, opts ...REPLOption) error {
+	cnf := &replConfig{
+		prompt:  root.FlagSet.Name() + "> ",
+		histMax: 500,
+	}
+	for _, opt := range opts {
+		opt(cnf)
+	}
+
+	var hist []string
+	readLine := replLineReader(root, in, out)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		line, err := readLine(cnf.prompt, hist)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		if strings.HasPrefix(line, "!") {
+			recalled, ok := recallLine(hist, line)
+			if !ok {
+				fmt.Fprintln(out, root.Catalog.Sprintf(MsgHistoryNotFound, line))
+				continue
+			}
+			line = recalled
+			fmt.Fprintln(out, line)
+		}
+
+		args, err := splitLine(line)
+		if err != nil {
+			err = cerrs.NewError(cerrs.NewParseError(err))
+			fmt.Fprintln(out, root.Catalog.UserFriendlyError(err))
+			continue
+		}
+		if len(args) == 0 {
+			continue
+		}
+
+		hist = append(hist, line)
+		if len(hist) > cnf.histMax {
+			hist = hist[len(hist)-cnf.histMax:]
+		}
+
+		if root.lookupSub(args[0]) == nil {
+			switch args[0] {
+			case "exit", "quit":
+				return nil
+
+			case "history":
+				for i, h := range hist {
+					fmt.Fprintf(out, "%4d  %s\n", i+1, h)
+				}
+				continue
+
+			case "help":
+				fmt.Fprint(out, root.lookupPath(args[1:]).Usage())
+				continue
+			}
+		}
+
+		cmd, err := root.Parse(args)
+		if err != nil {
+			fmt.Fprintln(out, cmd.Catalog.UserFriendlyError(err))
+			continue
+		}
+
+		if cmd.Handler == nil {
+			fmt.Fprint(out, cmd.Usage())
+			continue
+		}
+
+		if err := cmd.Handle(ctx); err != nil {
+			fmt.Fprintln(out, err)
+		}
+	}
+}
+
+// recallLine returns the history entry referenced by "!!" (the previous line)
+// or "!N" (line N, as numbered by the history command).
+func recallLine(hist []string, ref string) (string, bool) {
+	i := len(hist) - 1
+	if ref != "!!" {
+		n, err := strconv.Atoi(ref[1:])
+		if err != nil {
+			return "", false
+		}
+		i = n - 1
+	}
+
+	if i < 0 || i >= len(hist) {
+		return "", false
+	}
+	return hist[i], true
+}
+
+// replLineReader returns a func that writes a prompt and reads a line. A line
+// editor is used if in is a terminal that supports raw mode.
+func replLineReader(root *Clic, in io.Reader, out io.Writer) func(string, []string) (string, error) {
+	if f, ok := in.(*os.File); ok && isTerminal(f) {
+		if restore, err := enableRaw(f); err == nil {
+			restore()
+
+			ed := &lineEditor{
+				r:        bufio.NewReader(f),
+				out:      out,
+				complete: func(before string) []string { return replComplete(root, before) },
+			}
+			return func(prompt string, hist []string) (string, error) {
+				restore, err := enableRaw(f)
+				if err != nil {
+					return "", err
+				}
+				defer restore()
+
+				return ed.readLine(prompt, hist)
+			}
+		}
+	}
+
+	scanner := bufio.NewScanner(in)
+	return func(prompt string, _ []string) (string, error) {
+		fmt.Fprint(out, prompt)
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			if err := scanner.Err(); err != nil {
+				return "", err
+			}
+			return "", io.EOF
+		}
+		return scanner.Text(), nil
+	}
+}
+
+// replComplete returns the completion candidates for the last word of the
+// partial line.
+func replComplete(root *Clic, line string) []string {
+	args, err := splitLine(line)
+	if err != nil {
+		return nil
+	}
+	if line == "" || strings.ContainsRune(" \t", rune(line[len(line)-1])) {
+		args = append(args, "")
+	}
+
+	return root.Complete(args)
+}
+
+// lookupPath returns the deepest subcommand matching the provided names.
+func (c *Clic) lookupPath(names []string) *Clic {
+	cmd := c
+	for _, name := range names {
+		sub := cmd.lookupSub(name)
+		if sub == nil {
+			break
+		}
+		cmd = sub
+		cmd.materialize()
+	}
+	return cmd
+}
@@ -0,0 +1,102 @@
+package clic
+
+import (
+	"reflect"
+
+	"github.com/daved/vtypes"
+)
+
+// Reset restores the flag and operand values of the Clic instance and all its
+// subcommands to the values they held when added using [Clic.Flag] and
+// [Clic.Operand]. State recorded by Parse (e.g. warnings, flag provenance, and
+// passthrough args) is cleared. Values added directly to a FlagSet or
+// OperandSet, and function values, are not affected. Unbuilt placeholders (see
+// [NewLazy]) are skipped.
+func (c *Clic) Reset() {
+	if c.build != nil {
+		return
+	}
+
+	c.reset()
+	for _, sub := range c.subs {
+		sub.Reset()
+	}
+}
+
+func (c *Clic) reset() {
+	for _, restore := range c.restores {
+		restore()
+	}
+
+	_ = c.FlagSet.Parse(nil) // clears parsed args; cannot fail without args
+	c.calledAs = ""
+	c.passArgs = nil
+	c.inv = nil
+	c.flagSrcs = nil
+	c.argSrcs = false
+	c.warnings = nil
+}
+
+// resetParsed resets the commands touched by the previous call to Parse.
+func (c *Clic) resetParsed() {
+	for _, cmd := range c.lastPath {
+		cmd.reset()
+	}
+	c.lastPath = nil
+}
+
+// snapshot returns a function that restores val (as provided by the caller) to
+// its current value. Conv is the value after [vtypes.ConvertCompatible] was
+// applied. A nil function is returned if the value cannot be restored.
+func snapshot(val, conv any) func() {
+	vo := reflect.ValueOf(val)
+	if vo.Kind() != reflect.Pointer || vo.IsNil() {
+		return nil
+	}
+	el := vo.Elem()
+
+	if s, ok := conv.(*vtypes.Slice); ok && s != val {
+		if el.Kind() != reflect.Slice {
+			return nil
+		}
+
+		def := cloneSliceValue(el)
+		return func() {
+			el.Set(cloneSliceValue(def))
+
+			fresh := vtypes.MakeSlice(val) // resets accumulation state
+			fresh.TypeName = s.TypeName
+			fresh.SplitEach = s.SplitEach
+			fresh.Separator = s.Separator
+			fresh.NonAccum = s.NonAccum
+			*s = fresh
+		}
+	}
+
+	if el.Kind() == reflect.Func || !el.CanSet() {
+		return nil
+	}
+
+	def := reflect.New(el.Type()).Elem()
+	def.Set(el)
+	return func() {
+		el.Set(def)
+	}
+}
+
+func cloneSliceValue(v reflect.Value) reflect.Value {
+	if v.IsNil() {
+		return reflect.Zero(v.Type())
+	}
+	out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+	reflect.Copy(out, v)
+	return out
+}
+
+// appendRestore records a snapshot of val, if possible.
+func appendRestore(restores []func(), val, conv any) []func() {
+	if restore := snapshot(val, conv); restore != nil {
+		return append(restores, restore)
+	}
+	return restores
+}
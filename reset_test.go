@@ -0,0 +1,62 @@
+package clic
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClicResetOnParse(t *testing.T) {
+	var (
+		info  = "default"
+		tags  = []string{"a"}
+		opnd  = "unset"
+		force bool
+	)
+
+	sub := New(nil, "subcmd")
+	sub.Flag(&tags, "tag", "")
+	sub.Flag(&force, "force", "")
+	sub.Operand(&opnd, false, "opnd", "")
+
+	root := New(nil, "myapp", sub)
+	root.Flag(&info, "info", "")
+	root.ResetOnParse = true
+
+	check := func(wantInfo string, wantTags []string, wantOpnd string, wantForce bool) {
+		t.Helper()
+		got := []any{info, tags, opnd, force}
+		want := []any{wantInfo, wantTags, wantOpnd, wantForce}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("got: %v, want: %v", got, want)
+		}
+	}
+
+	args := []string{"--info=x", "subcmd", "--tag=b", "--tag=c", "--force", "val"}
+	for i := 0; i < 2; i++ {
+		cmd, err := root.Parse(args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check("x", []string{"b", "c"}, "val", true)
+
+		if got := cmd.FlagSource("force").Source; got != SourceCLI {
+			t.Fatalf("source: got: %v, want: %v", got, SourceCLI)
+		}
+	}
+
+	cmd, err := root.Parse([]string{"subcmd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("default", []string{"a"}, "unset", false)
+
+	if got := cmd.FlagSource("force").Source; got != SourceDefault {
+		t.Fatalf("source: got: %v, want: %v", got, SourceDefault)
+	}
+
+	if _, err := root.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	root.Reset()
+	check("default", []string{"a"}, "unset", false)
+}
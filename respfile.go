@@ -0,0 +1,100 @@
+package clic
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+const maxResponseFileDepth = 10
+
+// ResponseFileError describes a failure to expand a response file. Line is
+// zero when the failure is not related to a specific line.
+type ResponseFileError struct {
+	child error
+	Path  string
+	Line  int
+}
+
+// Error implements the error interface.
+func (e *ResponseFileError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("response file %s: %v", e.Path, e.child)
+	}
+	return fmt.Sprintf("response file %s:%d: %v", e.Path, e.Line, e.child)
+}
+
+// Unwrap implements the [errors] Unwrap anonymous interface.
+func (e *ResponseFileError) Unwrap() error {
+	return e.child
+}
+
+// Is implements the [errors] Is anonymous interface.
+func (e *ResponseFileError) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// expandResponseFiles replaces args of the form "@path" with the args read from
+// the file at path. Files are split line by line using the same rules as
+// [Clic.ParseLine], and may reference other response files. Args following
+// "--" are not expanded.
+func expandResponseFiles(args []string, depth int) ([]string, error) {
+	var out []string
+
+	for i, arg := range args {
+		if arg == "--" {
+			return append(out, args[i:]...), nil
+		}
+		if len(arg) < 2 || arg[0] != '@' {
+			out = append(out, arg)
+			continue
+		}
+
+		path := arg[1:]
+		if depth >= maxResponseFileDepth {
+			return nil, &ResponseFileError{ErrResponseFileDepth, path, 0}
+		}
+
+		fileArgs, err := readResponseFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		fileArgs, err = expandResponseFiles(fileArgs, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, fileArgs...)
+	}
+
+	return out, nil
+}
+
+func readResponseFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, &ResponseFileError{err, path, 0}
+	}
+	defer f.Close()
+
+	var args []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024)
+
+	for n := 1; scanner.Scan(); n++ {
+		lineArgs, err := splitLine(strings.TrimSuffix(scanner.Text(), "\r"))
+		if err != nil {
+			return nil, &ResponseFileError{err, path, n}
+		}
+		args = append(args, lineArgs...)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, &ResponseFileError{err, path, 0}
+	}
+
+	return args, nil
+}
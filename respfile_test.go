@@ -0,0 +1,99 @@
+package clic
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestClicParseResponseFiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	nested := write("nested", "--tag c\n")
+	main := write("main", "--tag a --tag 'b b'\n@"+nested+"\n")
+	bad := write("bad", "--tag a\n--tag 'b\n")
+	loop := filepath.Join(dir, "loop")
+	write("loop", "@"+loop)
+
+	none := filepath.Join(dir, "none")
+	_, noneErr := os.Open(none)
+
+	tt := []struct {
+		name  string
+		args  []string
+		tags  []string
+		opnds []string
+		cause error
+		msg   string
+	}{
+		{"expanded", []string{"@" + main, "x"}, []string{"a", "b b", "c"}, []string{"x"}, nil, ""},
+		{"after terminator", []string{"--", "@" + main}, nil, []string{"@" + main}, nil, ""},
+		{
+			"missing", []string{"@" + none}, nil, nil, fs.ErrNotExist,
+			fmt.Sprintf("Cannot read response file %q (%v)", none, errors.Unwrap(noneErr)),
+		},
+		{
+			"unterminated", []string{"@" + bad}, nil, nil, CauseParseLineUnterminated,
+			fmt.Sprintf("Cannot read line 2 of response file %q (Unterminated quote or escape)", bad),
+		},
+		{
+			"depth", []string{"@" + loop}, nil, nil, CauseParseResponseDepth,
+			fmt.Sprintf("Response file %q is nested too deeply", loop),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var tags []string
+
+			root := New(nil, "myapp")
+			root.Flag(&tags, "tag", "")
+			root.ResponseFiles = true
+
+			resolved, err := root.Parse(tc.args)
+			if !errors.Is(err, tc.cause) {
+				t.Fatalf("error: got: %v, want: %v", err, tc.cause)
+			}
+			if err != nil {
+				if !errors.Is(err, CauseParseResponseFile) {
+					t.Fatalf("error: got: %v, want: %v", err, CauseParseResponseFile)
+				}
+				if got := UserFriendlyError(err).Error(); got != tc.msg {
+					t.Fatalf("message: got: %q, want: %q", got, tc.msg)
+				}
+				return
+			}
+
+			if !reflect.DeepEqual(tags, tc.tags) {
+				t.Fatalf("tags: got: %q, want: %q", tags, tc.tags)
+			}
+			if got := resolved.FlagSet.Operands(); !reflect.DeepEqual(got, tc.opnds) {
+				t.Fatalf("operands: got: %q, want: %q", got, tc.opnds)
+			}
+		})
+	}
+
+	resErr := (*ResponseFileError)(nil)
+	_, err := New(nil, "myapp").Parse([]string{"@" + bad})
+	if err != nil {
+		t.Fatalf("disabled: got: %v, want: nil", err)
+	}
+
+	root := New(nil, "myapp")
+	root.ResponseFiles = true
+	_, err = root.Parse([]string{"@" + bad})
+	if !errors.As(err, &resErr) || resErr.Path != bad || resErr.Line != 2 {
+		t.Fatalf("location: got: %v, want: %s:2", err, bad)
+	}
+}
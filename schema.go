@@ -0,0 +1,390 @@
+package clic
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/daved/clic/cerrs"
+	"github.com/daved/flagset"
+	"github.com/daved/flagset/fserrs"
+	"github.com/daved/operandset"
+	"github.com/daved/vtypes"
+)
+
+// JSON argument errors are returned by [Clic.ParseJSON].
+var (
+	ErrPropertyUnknown = errors.New("property unknown")
+	ErrPropertyInvalid = errors.New("property invalid")
+)
+
+// Enumer describes flag and operand value types that accept a fixed set of
+// values. The values are reported as an enum by [Clic.JSONSchema].
+type Enumer interface {
+	EnumValues() []string
+}
+
+// JSONSchema is the subset of JSON Schema used to describe command arguments.
+type JSONSchema struct {
+	Type                 string                 `json:"type,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Default              any                    `json:"default,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+}
+
+// Tool describes a command that can be invoked using JSON arguments (see
+// [Clic.ParseJSON]).
+type Tool struct {
+	Name        string      `json:"name"` // command path joined by "_"
+	Description string      `json:"description,omitempty"`
+	InputSchema *JSONSchema `json:"inputSchema"`
+	Cmd         *Clic       `json:"-"`
+}
+
+// Tools returns a Tool for each leaf command (i.e. a command without
+// subcommands) of the provided Clic instance. Hidden and deprecated commands
+// (and their subcommands), commands without a handler, and commands that
+// capture passthrough args are skipped. Lazy placeholders (see [NewLazy]) are
+// built.
+func Tools(c *Clic) []*Tool {
+	c.materialize()
+
+	if c.HideUsage || c.Deprecated != nil {
+		return nil
+	}
+
+	if len(c.subs) > 0 {
+		var tools []*Tool
+		for _, sub := range c.subs {
+			tools = append(tools, Tools(sub)...)
+		}
+		return tools
+	}
+
+	if c.Handler == nil || c.Passthrough {
+		return nil
+	}
+
+	var names []string
+	for _, cmd := range cmdPath(nil, c) {
+		names = append(names, cmd.FlagSet.Name())
+	}
+
+	return []*Tool{{
+		Name:        strings.Join(names, "_"),
+		Description: c.Description,
+		InputSchema: c.JSONSchema(),
+		Cmd:         c,
+	}}
+}
+
+// JSONSchema returns a schema describing the JSON object accepted by
+// [Clic.ParseJSON]. Operands are named by their name, and flags by their first
+// long name (or short name if there are no long names). Flags of parent
+// commands are included unless their name is already used. Hidden flags, and
+// values not added using [Clic.Flag] or [Clic.Operand], are skipped.
+//
+// Flag defaults are taken from the flag's DefaultText, and operand defaults
+// from the current values, so schemas should be generated before parsing (e.g.
+// using [Blueprint.Root]).
+func (c *Clic) JSONSchema() *JSONSchema {
+	c.materialize()
+
+	noExtra := false
+	s := &JSONSchema{
+		Type:                 "object",
+		Description:          c.Description,
+		Properties:           make(map[string]*JSONSchema),
+		AdditionalProperties: &noExtra,
+	}
+
+	for _, p := range jsonParams(c) {
+		if p.op == nil {
+			s.Properties[p.name] = valueSchema(p.val, p.flag.Description(), p.flag.DefaultText)
+			continue
+		}
+
+		ps := valueSchema(p.val, p.op.Description(), vtypes.DefaultValueText(p.val))
+		if ps.Items != nil { // operands hold one value each
+			ps.Type, ps.Items, ps.Default = ps.Items.Type, nil, nil
+		}
+		s.Properties[p.name] = ps
+
+		if p.op.IsRequired() {
+			s.Required = append(s.Required, p.name)
+		}
+	}
+
+	return s
+}
+
+// ParseJSON hydrates the flags and operands of the Clic instance (and the flags
+// of its parent commands) from a JSON object described by [Clic.JSONSchema].
+// Values may be JSON strings, numbers, or booleans, and flags that accept
+// multiple values may also be set using arrays. Unknown properties are
+// reported as [ErrPropertyUnknown], and unusable values as
+// [ErrPropertyInvalid]. An operand cannot be set unless all preceding operands
+// are set.
+//
+// After a successful call, the Clic instance can be handled as though Parse had
+// resolved to it. Settings of the root command such as ResetOnParse, Prompter,
+// and WarningFunc are respected. Flags are recorded as set on the command line
+// (see [Clic.FlagSource]).
+func (c *Clic) ParseJSON(data []byte) error {
+	wrap := func(err error) error {
+		return cerrs.NewError(cerrs.NewParseError(err))
+	}
+
+	c.materialize()
+	path := cmdPath(nil, c)
+	root := path[0]
+
+	if root.ResetOnParse {
+		root.resetParsed()
+	}
+	root.lastPath = path
+
+	for _, cmd := range path {
+		_ = cmd.FlagSet.Parse(nil) // clears parsed args; cannot fail without args
+		cmd.calledAs = cmd.FlagSet.Name()
+		cmd.passArgs = nil
+		cmd.inv = nil
+	}
+	clearFlagSources(path)
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return wrap(fmt.Errorf("%w: %w", ErrPropertyInvalid, err))
+	}
+
+	params := jsonParams(c)
+	opRaws := make(map[*operandset.Operand]string)
+
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		i := slices.IndexFunc(params, func(p jsonParam) bool { return p.name == name })
+		if i < 0 {
+			return wrap(fmt.Errorf("%w: %q", ErrPropertyUnknown, name))
+		}
+		p := params[i]
+
+		raws, err := jsonRaws(obj[name])
+		if err != nil {
+			return wrap(fmt.Errorf("%w: %q: %w", ErrPropertyInvalid, name, err))
+		}
+		if raws == nil {
+			continue
+		}
+
+		if p.op != nil {
+			if len(raws) != 1 {
+				return wrap(fmt.Errorf("%w: %q: expects a single value", ErrPropertyInvalid, name))
+			}
+			opRaws[p.op] = raws[0]
+			continue
+		}
+
+		if _, ok := p.val.(*vtypes.Slice); !ok && len(raws) != 1 {
+			return wrap(fmt.Errorf("%w: %q: expects a single value", ErrPropertyInvalid, name))
+		}
+
+		for _, raw := range raws {
+			if err := vtypes.Hydrate(p.val, raw); err != nil {
+				return wrap(fserrs.NewResolveError(err, name))
+			}
+		}
+		p.owner.setFlagSource(p.flag, Provenance{SourceCLI, strings.Join(raws, ",")})
+	}
+
+	var args []string
+	ops := c.OperandSet.Operands()
+	for i, op := range ops {
+		raw, ok := opRaws[op]
+		if !ok {
+			continue
+		}
+		if len(args) < i {
+			return wrap(fmt.Errorf("%w: %q: preceding operand %q not set", ErrPropertyInvalid, op.Name(), ops[len(args)].Name()))
+		}
+		args = append(args, raw)
+	}
+
+	root.recordWarnings(path)
+
+	if err := c.parseOperands(root.Prompter, args); err != nil {
+		return wrap(err)
+	}
+
+	c.inv = newInvocation(path, nil)
+
+	return nil
+}
+
+// jsonParam relates a JSON property name to a flag or operand.
+type jsonParam struct {
+	name  string
+	owner *Clic
+	flag  *flagset.Flag
+	op    *operandset.Operand
+	val   any
+}
+
+// jsonParams returns the properties accepted by ParseJSON in schema order.
+func jsonParams(c *Clic) []jsonParam {
+	var params []jsonParam
+	seen := make(map[string]bool)
+
+	add := func(p jsonParam) {
+		if p.name == "" || p.val == nil || seen[p.name] {
+			return
+		}
+		seen[p.name] = true
+		params = append(params, p)
+	}
+
+	for _, op := range c.OperandSet.Operands() {
+		add(jsonParam{op.Name(), c, nil, op, c.opVals[op]})
+	}
+
+	for cmd := c; cmd != nil; cmd = cmd.parent {
+		for _, flag := range cmd.FlagSet.Flags() {
+			if flag.HideUsage {
+				continue
+			}
+
+			names := append(append([]string{}, flag.Longs()...), flag.Shorts()...)
+			if len(names) == 0 {
+				continue
+			}
+			add(jsonParam{names[0], cmd, flag, nil, cmd.flagVals[flag]})
+		}
+	}
+
+	return params
+}
+
+// valueSchema returns the schema of a flag or operand value.
+func valueSchema(val any, desc, defText string) *JSONSchema {
+	s := &JSONSchema{Description: desc}
+
+	typeName := vtypes.ValueTypeName(val)
+	if _, ok := val.(*vtypes.Slice); ok {
+		typeName, _, _ = strings.Cut(typeName, "(")
+		s.Type = "array"
+		s.Items = &JSONSchema{Type: jsonType(typeName)}
+		return s
+	}
+
+	s.Type = jsonType(typeName)
+	if e, ok := val.(Enumer); ok {
+		s.Enum = e.EnumValues()
+	}
+	s.Default = jsonDefault(s.Type, defText)
+
+	return s
+}
+
+// jsonType returns the JSON type of the named value type.
+func jsonType(typeName string) string {
+	switch typeName {
+	case "bool":
+		return "boolean"
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64":
+		return "integer"
+	case "float32", "float64":
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+// jsonDefault converts default text to a value of the JSON type. Nil is
+// returned if the text is empty or cannot be converted.
+func jsonDefault(typ, text string) any {
+	if text == "" {
+		return nil
+	}
+
+	switch typ {
+	case "boolean":
+		if b, err := strconv.ParseBool(text); err == nil {
+			return b
+		}
+	case "integer":
+		if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(text, 64); err == nil {
+			return f
+		}
+	case "string":
+		return text
+	}
+	return nil
+}
+
+// jsonRaws converts a JSON scalar or array of scalars to raw text values. Nil
+// is returned for JSON null.
+func jsonRaws(data json.RawMessage) ([]string, error) {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil, nil
+	}
+
+	if len(data) > 0 && data[0] == '[' {
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, err
+		}
+
+		raws := make([]string, 0, len(items))
+		for _, item := range items {
+			raw, err := jsonRaw(item)
+			if err != nil {
+				return nil, err
+			}
+			raws = append(raws, raw)
+		}
+		return raws, nil
+	}
+
+	raw, err := jsonRaw(data)
+	if err != nil {
+		return nil, err
+	}
+	return []string{raw}, nil
+}
+
+// jsonRaw converts a JSON scalar to raw text.
+func jsonRaw(data json.RawMessage) (string, error) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return "", err
+	}
+
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return string(bytes.TrimSpace(data)), nil // retain precision and format
+	default:
+		return "", errors.New("value must be a string, number, or boolean")
+	}
+}
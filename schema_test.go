@@ -0,0 +1,133 @@
+package clic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type testColor string
+
+func (c *testColor) Set(s string) error {
+	if !slices.Contains(c.EnumValues(), s) {
+		return fmt.Errorf("invalid color %q", s)
+	}
+	*c = testColor(s)
+	return nil
+}
+
+func (c *testColor) String() string       { return string(*c) }
+func (c *testColor) EnumValues() []string { return []string{"red", "green"} }
+
+func newSchemaClic(out *string) *Clic {
+	var (
+		verbose bool
+		count   = 2
+		tags    []string
+		color   = testColor("red")
+		name    string
+		note    string
+	)
+
+	paint := NewFromFunc(func(ctx context.Context) error {
+		*out = fmt.Sprintf("%t %d %v %s %s %s", verbose, count, tags, color, name, note)
+		return nil
+	}, "paint")
+	paint.Description = "Paint things"
+	paint.Flag(&count, "count|c", "number of coats")
+	paint.Flag(&tags, "tag", "tags to apply")
+	paint.Flag(&color, "color", "paint color")
+	paint.Operand(&name, true, "name", "thing to paint")
+	paint.Operand(&note, false, "note", "optional note")
+
+	hidden := NewFromFunc(func(ctx context.Context) error { return nil }, "hidden")
+	hidden.HideUsage = true
+
+	root := NewFromFunc(nil, "myapp", paint, hidden)
+	root.Flag(&verbose, "verbose|v", "verbose output")
+	root.Flag(&count, "count", "shadowed by subcommand")
+	return root
+}
+
+func TestTools(t *testing.T) {
+	var out string
+	tools := Tools(newSchemaClic(&out))
+
+	if len(tools) != 1 || tools[0].Name != "myapp_paint" {
+		t.Fatalf("tools: got: %v", tools)
+	}
+
+	got, err := json.Marshal(tools[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"name":"myapp_paint","description":"Paint things","inputSchema":{` +
+		`"type":"object","description":"Paint things","properties":{` +
+		`"color":{"type":"string","description":"paint color","default":"red","enum":["red","green"]},` +
+		`"count":{"type":"integer","description":"number of coats","default":2},` +
+		`"name":{"type":"string","description":"thing to paint"},` +
+		`"note":{"type":"string","description":"optional note"},` +
+		`"tag":{"type":"array","description":"tags to apply","items":{"type":"string"}},` +
+		`"verbose":{"type":"boolean","description":"verbose output","default":false}},` +
+		`"required":["name"],"additionalProperties":false}}`
+
+	if string(got) != want {
+		t.Errorf("got:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestClicParseJSON(t *testing.T) {
+	tt := []struct {
+		name  string
+		input string
+		want  string
+		err   error
+	}{
+		{"basic", `{"name":"fence"}`, "false 2 [] red fence ", nil},
+		{
+			"all",
+			`{"verbose":true,"count":3,"tag":["a","b"],"color":"green","name":"-x1","note":"dry"}`,
+			"true 3 [a b] green -x1 dry", nil,
+		},
+		{"unknown", `{"name":"fence","size":1}`, "", ErrPropertyUnknown},
+		{"object value", `{"name":{}}`, "", ErrPropertyInvalid},
+		{"array for single", `{"name":"fence","count":[1,2]}`, "", ErrPropertyInvalid},
+		{"operand gap", `{"note":"dry"}`, "", ErrPropertyInvalid},
+		{"operand missing", `{}`, "", CauseParseOperandRequired},
+		{"invalid enum", `{"name":"fence","color":"blue"}`, "", CauseParseFlagResolve},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var out string
+			cmd := Tools(newSchemaClic(&out))[0].Cmd
+
+			err := cmd.ParseJSON([]byte(tc.input))
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("error: got: %v, want: %v", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := cmd.Handle(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if out != tc.want {
+				t.Errorf("got: %q, want: %q", out, tc.want)
+			}
+
+			if want := []string{"myapp", "paint"}; !reflect.DeepEqual(cmd.inv.Path, want) {
+				t.Errorf("path: got: %v, want: %v", cmd.inv.Path, want)
+			}
+		})
+	}
+}
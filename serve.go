@@ -0,0 +1,131 @@
+package clic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// maxServeBody limits the size of request bodies accepted by NewHTTPHandler.
+const maxServeBody = 1 << 20
+
+// ServeRequest is the JSON form of an invocation accepted by [NewHTTPHandler].
+type ServeRequest struct {
+	Args []string `json:"args"`
+}
+
+// ServeResponse is the JSON result of an invocation handled by
+// [NewHTTPHandler].
+type ServeResponse struct {
+	Stdout   string      `json:"stdout"`
+	Stderr   string      `json:"stderr"`
+	ExitCode int         `json:"exitCode"`
+	Error    *ServeError `json:"error,omitempty"`
+}
+
+// ServeError describes an error returned while parsing or handling an
+// invocation.
+type ServeError struct {
+	Kind    string `json:"kind"`    // "parse" or "handle"
+	Message string `json:"message"` // see [UserFriendlyError]
+	Detail  string `json:"detail"`  // the unmodified error text
+}
+
+// NewHTTPHandler returns an [http.Handler] that runs invocations against
+// command trees built by bp. Requests must use the POST method, and the body
+// must be either a [ServeRequest] (with a JSON content type) or a line of text
+// that is split as by [Clic.ParseLine]. Response files and prompting are
+// disabled (i.e. the ResponseFiles and Prompter fields of the root are cleared).
+//
+// The resolved command is handled with its standard output and error captured
+// (see [StdioFrom]) and an empty standard input. The captured output, exit
+// code, and any error are returned as a [ServeResponse]. Parse errors exit with
+// code 2 and write usage to the captured standard error. Handler errors exit
+// with code 1, unless the error provides its own code using an "ExitCode() int"
+// method (e.g. [PluginExitError]). Commands without a handler exit with code 1
+// and report [ErrHandlerMissing] after writing usage to the captured standard
+// error.
+//
+// The handler can be served on any listener, including a Unix socket (e.g.
+// using [net.Listen] with "unix" and [http.Serve]).
+func NewHTTPHandler(bp *Blueprint) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxServeBody))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Requests must not read the server's files or standard input.
+		root := bp.Root()
+		root.ResponseFiles = false
+		root.Prompter = nil
+
+		var cmd *Clic
+		if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "application/json" {
+			var req ServeRequest
+			if err := json.Unmarshal(body, &req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			cmd, err = root.Parse(req.Args)
+		} else {
+			cmd, err = root.ParseLine(strings.TrimSpace(string(body)))
+		}
+
+		resp := serve(r.Context(), cmd, err)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
+
+// serve handles cmd unless parseErr is set, and returns the result.
+func serve(ctx context.Context, cmd *Clic, parseErr error) *ServeResponse {
+	var stdout, stderr bytes.Buffer
+	resp := &ServeResponse{}
+
+	switch {
+	case parseErr != nil:
+		stderr.WriteString(cmd.Usage())
+		resp.ExitCode = 2
+		resp.Error = newServeError("parse", cmd, parseErr)
+
+	case cmd.Handler == nil:
+		stderr.WriteString(cmd.Usage())
+		resp.ExitCode = 1
+		resp.Error = newServeError("handle", cmd, ErrHandlerMissing)
+
+	default:
+		ctx = WithStdio(ctx, Stdio{In: strings.NewReader(""), Out: &stdout, Err: &stderr})
+		if err := cmd.Handle(ctx); err != nil {
+			resp.ExitCode = 1
+			if coder := (interface{ ExitCode() int })(nil); errors.As(err, &coder) {
+				resp.ExitCode = coder.ExitCode()
+			}
+			resp.Error = newServeError("handle", cmd, err)
+		}
+	}
+
+	resp.Stdout, resp.Stderr = stdout.String(), stderr.String()
+	return resp
+}
+
+func newServeError(kind string, cmd *Clic, err error) *ServeError {
+	return &ServeError{
+		Kind:    kind,
+		Message: cmd.Catalog.UserFriendlyError(err).Error(),
+		Detail:  err.Error(),
+	}
+}
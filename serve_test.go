@@ -0,0 +1,116 @@
+package clic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type exitCodeError struct{ code int }
+
+func (e exitCodeError) Error() string { return fmt.Sprintf("exit %d", e.code) }
+func (e exitCodeError) ExitCode() int { return e.code }
+
+func TestNewHTTPHandler(t *testing.T) {
+	bp := NewBlueprint(func() *Clic {
+		var name string
+		var code int
+
+		greet := NewFromFunc(func(ctx context.Context) error {
+			stdio := StdioFrom(ctx)
+			fmt.Fprintf(stdio.Out, "hello, %s\n", name)
+			fmt.Fprintln(stdio.Err, "greeted")
+			return nil
+		}, "greet")
+		greet.Operand(&name, true, "name", "")
+
+		fail := NewFromFunc(func(ctx context.Context) error {
+			if code > 0 {
+				return exitCodeError{code}
+			}
+			return errors.New("failed")
+		}, "fail")
+		fail.Flag(&code, "code", "")
+
+		group := New(nil, "group", New(nil, "leaf"))
+
+		root := NewFromFunc(nil, "myapp", greet, fail, group)
+		root.SubRequired = true
+		root.ResponseFiles = true
+		return root
+	})
+
+	secret := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(secret, []byte("s3cret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(NewHTTPHandler(bp))
+	defer srv.Close()
+
+	tt := []struct {
+		name     string
+		ctype    string
+		body     string
+		stdout   string
+		stderr   string
+		exitCode int
+		errKind  string
+	}{
+		{"json", "application/json", `{"args":["greet","Ada"]}`, "hello, Ada\n", "greeted\n", 0, ""},
+		{"line", "text/plain", `greet 'Ada L'`, "hello, Ada L\n", "greeted\n", 0, ""},
+		{"handle error", "text/plain", "fail", "", "", 1, "handle"},
+		{"exit code", "application/json; charset=utf-8", `{"args":["fail","--code=3"]}`, "", "", 3, "handle"},
+		{"parse error", "text/plain", "greet", "", "Usage:", 2, "parse"},
+		{"no handler", "text/plain", "group", "", "Usage:", 1, "handle"},
+		{"response file", "text/plain", "greet @" + secret, "hello, @" + secret + "\n", "greeted\n", 0, ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Post(srv.URL, tc.ctype, strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			var got ServeResponse
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+
+			if got.ExitCode != tc.exitCode {
+				t.Errorf("exit code: got: %d, want: %d", got.ExitCode, tc.exitCode)
+			}
+			if got.Stdout != tc.stdout {
+				t.Errorf("stdout: got: %q, want: %q", got.Stdout, tc.stdout)
+			}
+			if !strings.HasPrefix(got.Stderr, tc.stderr) {
+				t.Errorf("stderr: got: %q, want prefix: %q", got.Stderr, tc.stderr)
+			}
+
+			switch {
+			case tc.errKind == "" && got.Error != nil:
+				t.Errorf("error: got: %+v, want: nil", got.Error)
+			case tc.errKind != "" && (got.Error == nil || got.Error.Kind != tc.errKind):
+				t.Errorf("error: got: %+v, want kind: %s", got.Error, tc.errKind)
+			}
+		})
+	}
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("status: got: %d, want: %d", resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+}
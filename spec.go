@@ -0,0 +1,98 @@
+package clic
+
+import (
+	"github.com/daved/flagset"
+	"github.com/daved/operandset"
+	"github.com/daved/vtypes"
+)
+
+// Spec is a serializable description of a command tree. It is intended to be
+// exported (e.g. as JSON) so that changes to a CLI can be tracked and compared
+// using [DiffSpecs].
+type Spec struct {
+	Name        string         `json:"name"`
+	Aliases     []string       `json:"aliases,omitempty"`
+	Description string         `json:"description,omitempty"`
+	SubRequired bool           `json:"subRequired,omitempty"`
+	Examples    []Example      `json:"examples,omitempty"`
+	Flags       []*FlagSpec    `json:"flags,omitempty"`
+	Operands    []*OperandSpec `json:"operands,omitempty"`
+	SubCmds     []*Spec        `json:"subCmds,omitempty"`
+}
+
+// FlagSpec is a serializable description of a flag.
+type FlagSpec struct {
+	Longs       []string `json:"longs,omitempty"`
+	Shorts      []string `json:"shorts,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Default     string   `json:"default,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// OperandSpec is a serializable description of an operand.
+type OperandSpec struct {
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// NewSpec returns a Spec describing the provided Clic instance and all of its
+// subcommands. Hidden commands and flags are included since they can still be
+// called. Lazy placeholders (see [NewLazy]) are built.
+func NewSpec(c *Clic) *Spec {
+	c.materialize()
+
+	s := &Spec{
+		Name:        c.FlagSet.Name(),
+		Aliases:     c.Aliases,
+		Description: c.Description,
+		SubRequired: c.SubRequired,
+		Examples:    c.Examples,
+	}
+
+	for _, flag := range c.FlagSet.Flags() {
+		s.Flags = append(s.Flags, newFlagSpec(flag))
+	}
+
+	for _, op := range c.OperandSet.Operands() {
+		s.Operands = append(s.Operands, newOperandSpec(op, c.opVals[op]))
+	}
+
+	for _, sub := range c.subs {
+		s.SubCmds = append(s.SubCmds, NewSpec(sub))
+	}
+
+	return s
+}
+
+func newFlagSpec(flag *flagset.Flag) *FlagSpec {
+	return &FlagSpec{
+		Longs:       flag.Longs(),
+		Shorts:      flag.Shorts(),
+		Type:        flag.TypeName,
+		Default:     flag.DefaultText,
+		Description: flag.Description(),
+	}
+}
+
+// newOperandSpec returns an OperandSpec for op. The type is derived from val,
+// and is empty if the value is not tracked (see [Clic.Operand]).
+func newOperandSpec(op *operandset.Operand, val any) *OperandSpec {
+	var typ string
+	if val != nil {
+		typ = vtypes.ValueTypeName(val)
+	}
+
+	return &OperandSpec{
+		Name:        op.Name(),
+		Type:        typ,
+		Required:    op.IsRequired(),
+		Description: op.Description(),
+	}
+}
+
+// names returns all long and short names of the flag.
+func (s *FlagSpec) names() []string {
+	return append(append([]string{}, s.Longs...), s.Shorts...)
+}
@@ -0,0 +1,21 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package clic
+
+import (
+	"errors"
+	"os"
+)
+
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+func enableRaw(*os.File) (func(), error) {
+	return nil, errors.New("raw mode is not supported")
+}
+
+func disableEcho(*os.File) (func(), error) {
+	return nil, errors.New("disabling echo is not supported")
+}
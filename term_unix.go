@@ -0,0 +1,70 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package clic
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+func getTermios(f *os.File) (*syscall.Termios, error) {
+	t := &syscall.Termios{}
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL, f.Fd(), ioctlGetTermios, uintptr(unsafe.Pointer(t)),
+	)
+	if errno != 0 {
+		return nil, errno
+	}
+	return t, nil
+}
+
+func setTermios(f *os.File, t *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL, f.Fd(), ioctlSetTermios, uintptr(unsafe.Pointer(t)),
+	)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func isTerminal(f *os.File) bool {
+	_, err := getTermios(f)
+	return err == nil
+}
+
+// enableRaw disables line buffering, echo, and signal keys so that input can be
+// read a key at a time. Output processing is left enabled.
+func enableRaw(f *os.File) (func(), error) {
+	orig, err := getTermios(f)
+	if err != nil {
+		return nil, err
+	}
+
+	t := *orig
+	t.Iflag &^= syscall.ICRNL | syscall.IXON
+	t.Lflag &^= syscall.ECHO | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	t.Cc[syscall.VMIN] = 1
+	t.Cc[syscall.VTIME] = 0
+	if err := setTermios(f, &t); err != nil {
+		return nil, err
+	}
+
+	return func() { _ = setTermios(f, orig) }, nil
+}
+
+func disableEcho(f *os.File) (func(), error) {
+	orig, err := getTermios(f)
+	if err != nil {
+		return nil, err
+	}
+
+	t := *orig
+	t.Lflag &^= syscall.ECHO
+	if err := setTermios(f, &t); err != nil {
+		return nil, err
+	}
+
+	return func() { _ = setTermios(f, orig) }, nil
+}
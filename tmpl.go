@@ -3,226 +3,239 @@ package clic
 import (
 	"bytes"
 	"fmt"
+	"io"
+	"maps"
 	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"text/template"
 
 	"github.com/daved/flagset"
 )
 
-// Tmpl holds template configuration details.
+// Usage template block names. The default template ([NewUsageTmpl]) is made of
+// these blocks, and each can be overridden using [Tmpl.SetBlock] or
+// [Clic.SetUsageBlock]. The operands and footer blocks are empty by default.
+// The alternative styles (e.g. [NewGNUUsageTmpl]) only provide the footer
+// block.
+const (
+	UsageBlockSynopsis    = "synopsis"
+	UsageBlockDescription = "description"
+	UsageBlockFlags       = "flags"
+	UsageBlockOperands    = "operands"
+	UsageBlockAliases     = "aliases"
+	UsageBlockSubcommands = "subcommands"
+	UsageBlockExamples    = "examples"
+	UsageBlockFooter      = "footer"
+)
+
+// Tmpl holds template configuration details. The template is compiled on first
+// execution, and recompiled only when Text, Blocks, or the set of FMap names
+// change. Changes to FMap values are applied on each execution.
 type Tmpl struct {
-	Text string
-	FMap template.FuncMap
-	Data any
-}
+	Text   string
+	FMap   template.FuncMap
+	Data   any
+	Blocks map[string]string // named block overrides; see SetBlock
 
-// Execute parses the template text and funcmap, then executes it using the set
-// data.
-func (t *Tmpl) Execute() (string, error) {
-	tmpl := template.New("clic").Funcs(t.FMap)
+	mu    sync.Mutex
+	cache *tmplCache
+}
 
-	buf := &bytes.Buffer{}
+// tmplCache holds a compiled template and the configuration it was compiled
+// from.
+type tmplCache struct {
+	tmpl   *template.Template
+	text   string
+	blocks map[string]string
+	fNames []string
+}
 
-	tmpl, err := tmpl.Parse(t.Text)
-	if err != nil {
-		return "", err
+// SetBlock overrides the named block (i.e. "{{define "name"}}...{{end}}") of
+// the template text with text. The same data is provided to the block as to
+// the template text. See the UsageBlock constants for block names used by the
+// default template.
+func (t *Tmpl) SetBlock(name, text string) {
+	if t.Blocks == nil {
+		t.Blocks = make(map[string]string)
 	}
+	t.Blocks[name] = text
+}
 
-	if err := tmpl.Execute(buf, t.Data); err != nil {
+// Execute compiles the template text, block overrides, and funcmap (if
+// needed), then executes it using the set data.
+func (t *Tmpl) Execute() (string, error) {
+	buf := &bytes.Buffer{}
+	if err := t.ExecuteTo(buf); err != nil {
 		return "", err
 	}
-
 	return buf.String(), nil
 }
 
-// String calls the Execute method returning either the validly executed
-// template output or error message text.
-func (t *Tmpl) String() string {
-	s, err := t.Execute()
-	if err != nil {
-		s = fmt.Sprintf("%v\n", err)
-	}
-	return s
-}
-
-// NewUsageTmpl returns the default template configuration. This can be used as
-// an example of how to setup custom usage output templating.
-func NewUsageTmpl(c *Clic) *Tmpl {
-	type tmplData struct {
-		Cmd *Clic
-	}
+// ExecuteTo is like Execute, but writes the output to w. Output may be
+// partially written if an error occurs during execution.
+func (t *Tmpl) ExecuteTo(w io.Writer) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 
-	data := &tmplData{
-		Cmd: c,
+	tmpl, err := t.compile()
+	if err != nil {
+		return err
 	}
 
-	cmdSetFn := func(c *Clic) []*Clic {
-		all := []*Clic{c}
-
-		for c.parent != nil {
-			c = c.parent
-			all = append(all, c)
-		}
-
-		slices.Reverse(all)
+	return tmpl.Execute(w, t.Data)
+}
 
-		return all
+// compile returns the cached template if it is still valid, and otherwise
+// parses and caches a new one.
+func (t *Tmpl) compile() (*template.Template, error) {
+	fNames := make([]string, 0, len(t.FMap))
+	for name := range t.FMap {
+		fNames = append(fNames, name)
 	}
+	slices.Sort(fNames)
 
-	cmdSetHintFn := func(cmds []*Clic) string {
-		var out, sep string
-		for _, cmd := range cmds {
-			out += sep + cmd.FlagSet.Name()
-			sep = " "
-			if len(cmd.FlagSet.Flags()) > 0 {
-				out += sep + "[FLAGS]"
-			}
-		}
-		return out
+	if c := t.cache; c != nil && c.text == t.Text && maps.Equal(c.blocks, t.Blocks) &&
+		slices.Equal(c.fNames, fNames) {
+		return c.tmpl.Funcs(t.FMap), nil
 	}
+	t.cache = nil
 
-	subsAndOperandsHintFn := func(cmd *Clic) string {
-		var out, sep string
-		var anySubShowing bool
-
-		for _, sub := range cmd.SubCmds() {
-			if sub.HideUsage {
-				continue
-			}
-			anySubShowing = true
-
-			out += sep + sub.FlagSet.Name()
-			sep = "|"
-		}
-
-		if anySubShowing {
-			pre, suf := "[", "]"
-			if cmd.SubRequired {
-				pre, suf = "{", "}"
-			}
-			out = pre + out + suf
-
-			if len(cmd.OperandSet.Operands()) == 0 {
-				return " " + out
-			}
-
-			out += " | "
-			sep = ""
-		}
-
-		for _, op := range cmd.OperandSet.Operands() {
-			pre, suf := "[", "]"
-			if op.IsRequired() {
-				pre, suf = "<", ">"
-			}
-			out += sep + pre + op.Name() + suf
-			sep = " "
-		}
-
-		pre, suf := "{", "}"
-		if !anySubShowing {
-			pre, suf = "", ""
-		}
-		out = pre + out + suf
-
-		if out != "" {
-			out = " " + out
-		}
-		return out
+	tmpl, err := template.New("clic").Funcs(t.FMap).Parse(t.Text)
+	if err != nil {
+		return nil, err
 	}
 
-	unhiddenFlagsFn := func(flags []*flagset.Flag) []*flagset.Flag {
-		var out []*flagset.Flag
-		for _, flag := range flags {
-			if !flag.HideUsage {
-				out = append(out, flag)
-			}
-		}
-		return out
+	names := make([]string, 0, len(t.Blocks))
+	for name := range t.Blocks {
+		names = append(names, name)
 	}
+	slices.Sort(names)
 
-	subCmdCatsSortFn := func(c *Clic) []string {
-		sort := slices.Clone(c.SubCmdCatsSort)
-		for _, sub := range c.SubCmds() {
-			if c.SubCmdCatsSort == nil && c.Category == "" {
-				continue
-			}
-
-			if !slices.ContainsFunc(sort, func(s string) bool {
-				prefix, _, _ := strings.Cut(s, "|")
-				return prefix == sub.Category
-			}) {
-				sort = append(sort, sub.Category)
-			}
+	for _, name := range names {
+		text := t.Blocks[name]
+		if strings.TrimSpace(text) == "" { // empty bodies do not replace blocks
+			text = "{{print " + strconv.Quote(text) + "}}"
 		}
-		return sort
-	}
 
-	categoryLine := func(s string) string {
-		if s == "" {
-			return ""
+		if _, err := tmpl.New(name).Parse(text); err != nil {
+			return nil, err
 		}
-		name, desc, _ := strings.Cut(s, "|")
-		return fmt.Sprintf("%-18s %s", name, desc)
 	}
 
-	subCmdsByCategoryFn := func(subs []*Clic, category string) []*Clic {
-		return slices.DeleteFunc(slices.Clone(subs), func(c *Clic) bool {
-			cat, _, _ := strings.Cut(category, "|")
-			return c.HideUsage || c.Category != cat
-		})
-	}
+	t.cache = &tmplCache{tmpl, t.Text, maps.Clone(t.Blocks), fNames}
+	return tmpl, nil
+}
 
-	subCmdLine := func(c *Clic) string {
-		return fmt.Sprintf("%-18s %s", c.FlagSet.Name(), c.Description)
+// String calls the Execute method returning either the validly executed
+// template output or error message text.
+func (t *Tmpl) String() string {
+	s, err := t.Execute()
+	if err != nil {
+		s = fmt.Sprintf("%v\n", err)
 	}
+	return s
+}
 
-	fMap := template.FuncMap{
-		"CmdSet":              cmdSetFn,
-		"CmdSetHint":          cmdSetHintFn,
-		"SubsAndOperandsHint": subsAndOperandsHintFn,
-		"UnhiddenFlags":       unhiddenFlagsFn,
-		"StringsJoin":         strings.Join,
-		"SubCmdCatsSort":      subCmdCatsSortFn,
-		"CategoryLine":        categoryLine,
-		"SubCmdsByCategory":   subCmdsByCategoryFn,
-		"SubCmdLine":          subCmdLine,
-	}
+// NewUsageTmpl returns the default template configuration. This can be used as
+// an example of how to setup custom usage output templating. The data is a
+// [UsageView], and the funcmap is provided by [UsageFuncs].
+func NewUsageTmpl(c *Clic) *Tmpl {
+	data := NewUsageView(c)
+	fMap := UsageFuncs()
 
 	text := strings.TrimSpace(`
-{{- $cmd := .Cmd -}}
-{{- $cmdSet := CmdSet $cmd -}}
-{{- $unhiddenFlags := UnhiddenFlags $cmd.FlagSet.Flags -}}
-{{- $subCmdCatsSort := SubCmdCatsSort $cmd -}}
+{{- template "synopsis" .}}
+{{- template "description" .}}
+{{- template "flags" .}}
+{{- template "operands" .}}
+{{- template "aliases" .}}
+{{- template "subcommands" .}}
+{{- template "examples" .}}
+{{- template "footer" .}}
+
+{{- define "synopsis"}}{{$cmd := .Cmd -}}
 {{if 1 -}}
-Usage:
+{{Msg $cmd "usage"}}
 
-  {{CmdSetHint $cmdSet}}{{SubsAndOperandsHint $cmd}}
+  {{CmdSetHint .Path}}{{SubsAndOperandsHint $cmd}}{{if $cmd.Passthrough}} [-- ARGS...]{{end}}
 {{end -}}
+{{end}}
+
+{{- define "description"}}{{$cmd := .Cmd -}}
 {{if $cmd.Description}}
     {{$cmd.Description}}
 {{end -}}
-{{if $unhiddenFlags}}
-{{$cmd.FlagSet.Usage -}}
+{{if $cmd.Deprecated}}
+    {{Msg $cmd "deprecated"}}{{with $cmd.Deprecated.Text $cmd.Catalog}}: {{.}}{{end}}
 {{end -}}
-{{if $cmd.Aliases}}
-Aliases for {{$cmd.FlagSet.Name}}:
+{{end}}
+
+{{- define "flags"}}{{$cmd := .Cmd -}}
+{{if .Flags}}
+{{FlagSetUsage $cmd -}}
+{{end -}}
+{{end}}
+
+{{- define "operands"}}{{end}}
 
-      {{StringsJoin $cmd.Aliases ", "}}
+{{- define "aliases"}}{{$cmd := .Cmd -}}
+{{if .Aliases}}
+{{Msg $cmd "aliases-for" $cmd.FlagSet.Name}}
+
+      {{StringsJoin .Aliases ", "}}
 {{end -}}
+{{end}}
+
+{{- define "subcommands"}}{{$cmd := .Cmd -}}
+{{- $subCmdCatsSort := SubCmdCatsSort $cmd -}}
 {{if $subCmdCatsSort}}
-Subcommands for {{$cmd.FlagSet.Name}}:
-{{range $subCmdCatsSort -}}{{- $catLine := CategoryLine . -}}
+{{Msg $cmd "subcommands-for" $cmd.FlagSet.Name}}
+{{range $subCmdCatsSort -}}{{- $catLine := CategoryLine (CategoryName $cmd .) -}}
 {{if $catLine}}
   {{$catLine}}{{end}}
-{{range $_, $sub := SubCmdsByCategory $cmd.SubCmds . -}}
+{{range $_, $sub := SubCmdsByCategory (UsageSubCmds $cmd) . -}}
 {{if 1}}{{end}}    {{SubCmdLine $sub}}
 {{end -}}
 {{if 1}}{{end -}}
 {{end -}}
 {{end -}}
+{{end}}
+
+{{- define "examples"}}{{$cmd := .Cmd -}}
+{{if $cmd.Examples}}
+{{Msg $cmd "examples-for" $cmd.FlagSet.Name}}
+{{range $cmd.Examples}}
+    {{.Line}}{{with .Description}}
+        {{.}}{{end}}
+{{end -}}
+{{end -}}
+{{end}}
+
+{{- define "footer"}}{{end}}
 `)
 
-	return &Tmpl{text, fMap, data}
+	return &Tmpl{Text: text, FMap: fMap, Data: data}
+}
+
+// localizedFlagSetTmpl returns a copy of the FlagSet template with the heading
+// and default value hints drawn from the catalog.
+func localizedFlagSetTmpl(fs *flagset.FlagSet, cat Catalog) *flagset.Tmpl {
+	t := *fs.Tmpl
+
+	t.FMap = maps.Clone(t.FMap)
+	t.FMap["FlagsFor"] = func(name string) string {
+		return cat.Sprintf(MsgFlagsFor, name)
+	}
+	t.FMap["DefaultHint"] = func(f *flagset.Flag) string {
+		if f.DefaultText == "" {
+			return ""
+		}
+		return cat.Sprintf(MsgDefault, f.DefaultText)
+	}
+
+	t.Text = strings.Replace(t.Text, "Flags for {{.FlagSet.Name}}:", "{{FlagsFor .FlagSet.Name}}", 1)
+
+	return &t
 }
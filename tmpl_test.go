@@ -0,0 +1,91 @@
+package clic
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestClicSetUsageBlock(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	sub := NewCmdClic(buf, "sub", nil)
+	sub.Description = "Does things"
+	root := NewCmdClic(buf, "myapp", nil, sub)
+	before := sub.Usage()
+
+	root.SetUsageBlock(UsageBlockFooter, "\nDocs: https://example.com/{{.Cmd.FlagSet.Name}}\n")
+	sub.Tmpl.SetBlock(UsageBlockFlags, "")
+
+	got := sub.Usage()
+	want := strings.Replace(before, "\n"+sub.FlagSet.Usage(), "", 1) + "\nDocs: https://example.com/sub\n"
+	if got != want {
+		t.Errorf("sub: got:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := root.Usage(); !strings.HasSuffix(got, "\nDocs: https://example.com/myapp\n") ||
+		!strings.Contains(got, "Flags for myapp:") {
+		t.Errorf("root: got:\n%s", got)
+	}
+
+	root.Tmpl.SetBlock(UsageBlockSynopsis, "{{template")
+	if got := root.Usage(); !strings.Contains(got, "template: synopsis") {
+		t.Errorf("invalid block: got: %s", got)
+	}
+}
+
+func TestTmplCache(t *testing.T) {
+	greeting := "hello"
+	tmpl := &Tmpl{
+		Text: `{{Greet}} {{.}}`,
+		FMap: map[string]any{"Greet": func() string { return greeting }},
+		Data: "world",
+	}
+
+	check := func(want string) {
+		t.Helper()
+		buf := &bytes.Buffer{}
+		if err := tmpl.ExecuteTo(buf); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != want {
+			t.Errorf("got: %q, want: %q", got, want)
+		}
+	}
+
+	check("hello world")
+	cached := tmpl.cache.tmpl
+
+	greeting = "hi"
+	tmpl.Data = "there"
+	check("hi there")
+	if tmpl.cache.tmpl != cached {
+		t.Error("cache: recompiled without changes")
+	}
+
+	tmpl.FMap["Greet"] = func() string { return "hey" }
+	check("hey there")
+	if tmpl.cache.tmpl != cached {
+		t.Error("cache: recompiled for changed func value")
+	}
+
+	tmpl.Text = `{{Greet}}, {{.}}`
+	check("hey, there")
+	if tmpl.cache.tmpl == cached {
+		t.Error("cache: not recompiled for changed text")
+	}
+	cached = tmpl.cache.tmpl
+
+	tmpl.Text = `{{define "x"}}{{.}}{{end}}{{Greet}}, {{template "x" .}}`
+	tmpl.SetBlock("x", "{{Upper .}}")
+	tmpl.FMap["Upper"] = strings.ToUpper
+	check("hey, THERE")
+	if tmpl.cache.tmpl == cached {
+		t.Error("cache: not recompiled for changed blocks and funcs")
+	}
+
+	tmpl.Text = `{{`
+	if err := tmpl.ExecuteTo(&bytes.Buffer{}); err == nil || tmpl.cache != nil {
+		t.Errorf("invalid text: got: %v, cache: %v", err, tmpl.cache)
+	}
+}
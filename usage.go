@@ -0,0 +1,336 @@
+package clic
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/daved/flagset"
+	"github.com/daved/operandset"
+)
+
+// UsageView is the data model provided to the usage templates (see
+// [NewUsageTmpl] and the alternative styles such as [NewGNUUsageTmpl]). It is
+// intended to be set as the Data field of a [Tmpl] so that custom templates do
+// not need to derive details from the Clic instance. Values are derived from
+// Cmd when requested so that changes made after template construction are
+// reflected. In templates, methods are accessed as fields (e.g. "{{.Flags}}").
+type UsageView struct {
+	Cmd *Clic
+}
+
+// NewUsageView returns an instance of UsageView.
+func NewUsageView(c *Clic) *UsageView {
+	return &UsageView{Cmd: c}
+}
+
+// UsageCategory holds the visible subcommands of a category. The Name and
+// Desc fields are taken from the SubCmdCatsSort entry (i.e. "name|desc") of
+// the parent command when available.
+type UsageCategory struct {
+	Name string
+	Desc string
+	Cmds []*Clic
+}
+
+// Path returns the commands from the root command to Cmd.
+func (v *UsageView) Path() []*Clic {
+	return cmdSet(v.Cmd)
+}
+
+// Flags returns the visible flags of Cmd.
+func (v *UsageView) Flags() []*flagset.Flag {
+	return unhiddenFlags(v.Cmd.FlagSet.Flags())
+}
+
+// Parents returns the commands from the root command to the parent of Cmd.
+func (v *UsageView) Parents() []*Clic {
+	return cmdSet(v.Cmd.parent)
+}
+
+// InheritedFlags returns the visible flags of the parent commands of Cmd,
+// starting with the root command.
+func (v *UsageView) InheritedFlags() []*flagset.Flag {
+	var out []*flagset.Flag
+	for _, cmd := range cmdSet(v.Cmd.parent) {
+		out = append(out, unhiddenFlags(cmd.FlagSet.Flags())...)
+	}
+	return out
+}
+
+// Operands returns the operands of Cmd.
+func (v *UsageView) Operands() []*operandset.Operand {
+	return v.Cmd.OperandSet.Operands()
+}
+
+// Aliases returns the visible aliases of Cmd.
+func (v *UsageView) Aliases() []string {
+	return unhiddenAliases(v.Cmd)
+}
+
+// SubCmdCats returns the visible subcommands (including plugins) of Cmd
+// grouped by category. Categories are ordered by SubCmdCatsSort and then by
+// first use. Categories without visible subcommands are omitted.
+func (v *UsageView) SubCmdCats() []*UsageCategory {
+	var cats []*UsageCategory
+	lookup := func(name string) *UsageCategory {
+		for _, cat := range cats {
+			if cat.Name == name {
+				return cat
+			}
+		}
+		return nil
+	}
+
+	for _, s := range v.Cmd.SubCmdCatsSort {
+		name, desc, _ := strings.Cut(s, "|")
+		if lookup(name) == nil {
+			cats = append(cats, &UsageCategory{Name: name, Desc: desc})
+		}
+	}
+
+	for _, sub := range usageSubCmds(v.Cmd) {
+		if sub.HideUsage || sub.Deprecated != nil {
+			continue
+		}
+
+		cat := lookup(sub.Category)
+		if cat == nil {
+			cat = &UsageCategory{Name: sub.Category}
+			cats = append(cats, cat)
+		}
+		cat.Cmds = append(cat.Cmds, sub)
+	}
+
+	return slices.DeleteFunc(cats, func(cat *UsageCategory) bool {
+		return len(cat.Cmds) == 0
+	})
+}
+
+// UsageFuncs returns the funcmap used by the usage templates. It can be used
+// (and extended) by custom templates. The provided funcs are:
+//
+//   - CmdSet (*Clic) []*Clic: commands from the root command to the command
+//   - CmdSetHint ([]*Clic) string: command names with "[FLAGS]" hints
+//   - SubsAndOperandsHint (*Clic) string: visible subcommand and operand hints
+//   - UnhiddenFlags ([]*flagset.Flag) []*flagset.Flag: flags not hidden
+//   - UnhiddenAliases (*Clic) []string: aliases not deprecated
+//   - UsageSubCmds (*Clic) []*Clic: subcommands and plugin commands
+//   - SubCmdCatsSort (*Clic) []string: ordered categories ("name|desc")
+//   - SubCmdsByCategory ([]*Clic, string) []*Clic: visible commands in category
+//   - CategoryName (*Clic, string) string: localized category ("name|desc")
+//   - CategoryLine (string) string: formatted category name and description
+//   - SubCmdLine (*Clic) string: formatted command name and description
+//   - Msg (*Clic, string, ...any) string: localized text (see [Catalog])
+//   - FlagSetUsage (*Clic) string: localized flag set usage
+//   - FlagDescription (*Clic, *flagset.Flag) string: description and default
+//   - FlagsAppend ([]*flagset.Flag, []*flagset.Flag) []*flagset.Flag: joined
+//   - Row (int, string, string) string: left column padded to width, then right
+//   - StringsJoin: [strings.Join]
+//   - StringsToUpper: [strings.ToUpper]
+func UsageFuncs() template.FuncMap {
+	return template.FuncMap{
+		"CmdSet":              cmdSet,
+		"CmdSetHint":          cmdSetHint,
+		"SubsAndOperandsHint": subsAndOperandsHint,
+		"UnhiddenFlags":       unhiddenFlags,
+		"UnhiddenAliases":     unhiddenAliases,
+		"UsageSubCmds":        usageSubCmds,
+		"SubCmdCatsSort":      subCmdCatsSort,
+		"SubCmdsByCategory":   subCmdsByCategory,
+		"CategoryName":        categoryName,
+		"CategoryLine":        categoryLine,
+		"SubCmdLine":          subCmdLine,
+		"Msg":                 msg,
+		"FlagSetUsage":        flagSetUsage,
+		"FlagDescription":     flagDescription,
+		"FlagsAppend":         flagsAppend,
+		"Row":                 usageRow,
+		"StringsJoin":         strings.Join,
+		"StringsToUpper":      strings.ToUpper,
+	}
+}
+
+func cmdSet(c *Clic) []*Clic {
+	if c == nil {
+		return nil
+	}
+
+	all := []*Clic{c}
+
+	for c.parent != nil {
+		c = c.parent
+		all = append(all, c)
+	}
+
+	slices.Reverse(all)
+
+	return all
+}
+
+func cmdSetHint(cmds []*Clic) string {
+	var out, sep string
+	for _, cmd := range cmds {
+		out += sep + cmd.FlagSet.Name()
+		sep = " "
+		if len(cmd.FlagSet.Flags()) > 0 {
+			out += sep + "[FLAGS]"
+		}
+	}
+	return out
+}
+
+func subsAndOperandsHint(cmd *Clic) string {
+	var out, sep string
+	var anySubShowing bool
+
+	for _, sub := range cmd.SubCmds() {
+		if sub.HideUsage || sub.Deprecated != nil {
+			continue
+		}
+		anySubShowing = true
+
+		out += sep + sub.FlagSet.Name()
+		sep = "|"
+	}
+
+	if anySubShowing {
+		pre, suf := "[", "]"
+		if cmd.SubRequired {
+			pre, suf = "{", "}"
+		}
+		out = pre + out + suf
+
+		if len(cmd.OperandSet.Operands()) == 0 {
+			return " " + out
+		}
+
+		out += " | "
+		sep = ""
+	}
+
+	for _, op := range cmd.OperandSet.Operands() {
+		pre, suf := "[", "]"
+		if op.IsRequired() {
+			pre, suf = "<", ">"
+		}
+		out += sep + pre + op.Name() + suf
+		sep = " "
+	}
+
+	pre, suf := "{", "}"
+	if !anySubShowing {
+		pre, suf = "", ""
+	}
+	out = pre + out + suf
+
+	if out != "" {
+		out = " " + out
+	}
+	return out
+}
+
+func unhiddenFlags(flags []*flagset.Flag) []*flagset.Flag {
+	var out []*flagset.Flag
+	for _, flag := range flags {
+		if !flag.HideUsage {
+			out = append(out, flag)
+		}
+	}
+	return out
+}
+
+func usageSubCmds(c *Clic) []*Clic {
+	return append(slices.Clone(c.SubCmds()), c.PluginCmds()...)
+}
+
+func subCmdCatsSort(c *Clic) []string {
+	sort := slices.Clone(c.SubCmdCatsSort)
+	hasCat := func(cat string) bool {
+		return slices.ContainsFunc(sort, func(s string) bool {
+			prefix, _, _ := strings.Cut(s, "|")
+			return prefix == cat
+		})
+	}
+
+	for _, sub := range usageSubCmds(c) {
+		isPlugin := sub.Category == PluginsCategory
+		if c.SubCmdCatsSort == nil && c.Category == "" && !isPlugin {
+			continue
+		}
+
+		if !hasCat(sub.Category) {
+			sort = append(sort, sub.Category)
+		}
+	}
+	return sort
+}
+
+// categoryName returns the category (i.e. "name" or "name|desc") with the name
+// of the plugins category replaced by its localized text.
+func categoryName(c *Clic, s string) string {
+	name, desc, hasDesc := strings.Cut(s, "|")
+	if name != PluginsCategory {
+		return s
+	}
+
+	name = c.Catalog.Text(MsgPlugins)
+	if hasDesc {
+		return name + "|" + desc
+	}
+	return name
+}
+
+func categoryLine(s string) string {
+	if s == "" {
+		return ""
+	}
+	name, desc, _ := strings.Cut(s, "|")
+	return fmt.Sprintf("%-18s %s", name, desc)
+}
+
+func subCmdsByCategory(subs []*Clic, category string) []*Clic {
+	return slices.DeleteFunc(slices.Clone(subs), func(c *Clic) bool {
+		cat, _, _ := strings.Cut(category, "|")
+		return c.HideUsage || c.Deprecated != nil || c.Category != cat
+	})
+}
+
+func unhiddenAliases(c *Clic) []string {
+	return slices.DeleteFunc(slices.Clone(c.Aliases), func(alias string) bool {
+		return c.aliasDeprecation(alias) != nil
+	})
+}
+
+func msg(c *Clic, key string, args ...any) string {
+	return c.Catalog.Sprintf(key, args...)
+}
+
+func flagSetUsage(c *Clic) string {
+	if c.Catalog == nil {
+		return c.FlagSet.Usage()
+	}
+	return localizedFlagSetTmpl(c.FlagSet, c.Catalog).String()
+}
+
+func subCmdLine(c *Clic) string {
+	return fmt.Sprintf("%-18s %s", c.FlagSet.Name(), c.Description)
+}
+
+func flagDescription(c *Clic, flag *flagset.Flag) string {
+	desc := flag.Description()
+	if def := flagDefault(flag); def != "" {
+		desc = strings.TrimSpace(desc + " (" + c.Catalog.Sprintf(MsgDefault, def) + ")")
+	}
+	return desc
+}
+
+func flagsAppend(a, b []*flagset.Flag) []*flagset.Flag {
+	return append(append([]*flagset.Flag{}, a...), b...)
+}
+
+// usageRow returns left padded to width followed by right.
+func usageRow(width int, left, right string) string {
+	return strings.TrimRight(fmt.Sprintf("%-*s %s", width, left, right), " ")
+}
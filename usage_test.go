@@ -0,0 +1,48 @@
+package clic
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestUsageView(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	hidden := NewCmdClic(buf, "hidden", nil)
+	hidden.HideUsage = true
+	b := NewCmdClic(buf, "b", nil)
+	b.Category = "Extra"
+	leaf := NewCmdClic(buf, "leaf", nil)
+	a := NewCmdClic(buf, "a", nil, leaf)
+	a.Category = "Main"
+
+	root := NewCmdClic(buf, "myapp", nil, b, hidden, a, NewCmdClic(buf, "c", nil))
+	root.SubCmdCatsSort = []string{"Main|Main commands", "Unused"}
+
+	var got []string
+	for _, cat := range NewUsageView(root).SubCmdCats() {
+		s := cat.Name + "|" + cat.Desc + ":"
+		for _, cmd := range cat.Cmds {
+			s += " " + cmd.FlagSet.Name()
+		}
+		got = append(got, s)
+	}
+	want := []string{"Main|Main commands: a", "Extra|: b", "|: c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("categories: got: %q, want: %q", got, want)
+	}
+
+	v := NewUsageView(leaf)
+	if got := cmdNames(v.Path()); got != "myapp a leaf" {
+		t.Errorf("path: got: %s", got)
+	}
+
+	var inherited []string
+	for _, flag := range v.InheritedFlags() {
+		inherited = append(inherited, flag.Longs()...)
+	}
+	if want := []string{"info", "num", "info", "num"}; !reflect.DeepEqual(inherited, want) {
+		t.Errorf("inherited flags: got: %v, want: %v", inherited, want)
+	}
+}
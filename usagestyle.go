@@ -0,0 +1,374 @@
+package clic
+
+import (
+	"maps"
+	"strings"
+	"text/template"
+
+	"github.com/daved/flagset"
+	"github.com/daved/operandset"
+)
+
+// SetUsageStyle sets the Tmpl field of the Clic instance and all its
+// subcommands using the provided template construction function (e.g.
+// [NewCompactUsageTmpl]). Unbuilt placeholders (see [NewLazy]) are set when
+// built.
+func (c *Clic) SetUsageStyle(style func(*Clic) *Tmpl) {
+	c.Recursively(func(c *Clic) {
+		c.Tmpl = style(c)
+	})
+}
+
+// SetUsageBlock overrides the named usage template block (see the UsageBlock
+// constants) of the Clic instance and all its subcommands. Use [Tmpl.SetBlock]
+// to override a block of a single command. Overrides are lost if the Tmpl
+// field is replaced afterward (e.g. by SetUsageStyle). Unbuilt placeholders
+// (see [NewLazy]) are set when built.
+func (c *Clic) SetUsageBlock(name, text string) {
+	c.Recursively(func(c *Clic) {
+		c.Tmpl.SetBlock(name, text)
+	})
+}
+
+// NewCompactUsageTmpl returns a template configuration that prints a synopsis
+// followed by one line per flag, subcommand, and example, without section
+// headings.
+func NewCompactUsageTmpl(c *Clic) *Tmpl {
+	fMap := styleFuncs(template.FuncMap{
+		"Synopsis": compactSynopsis,
+		"FlagHint": compactFlagHint,
+	})
+
+	text := strings.TrimSpace(`
+{{- $cmd := .Cmd -}}
+{{Msg $cmd "usage"}} {{Synopsis .}}
+{{- with $cmd.Description}}
+  {{.}}
+{{- end}}
+{{- if $cmd.Deprecated}}
+  {{Msg $cmd "deprecated"}}{{with $cmd.Deprecated.Text $cmd.Catalog}}: {{.}}{{end}}
+{{- end}}
+{{- range .Flags}}
+  {{Row 20 (FlagHint $cmd .) .Description}}
+{{- end}}
+{{- range .SubCmdCats}}{{range .Cmds}}
+  {{Row 20 .FlagSet.Name .Description}}
+{{- end}}{{end}}
+{{- range $cmd.Examples}}
+  $ {{.Line}}{{with .Description}}  # {{.}}{{end}}
+{{- end}}
+{{- template "footer" .}}
+{{- define "footer"}}{{end}}
+`) + "\n"
+
+	return &Tmpl{Text: text, FMap: fMap, Data: NewUsageView(c)}
+}
+
+// NewGNUUsageTmpl returns a template configuration that follows the layout of
+// GNU "--help" output (e.g. "Usage: cmd [OPTION]... COMMAND"). Flags of parent
+// commands are listed in a section for each parent.
+func NewGNUUsageTmpl(c *Clic) *Tmpl {
+	fMap := styleFuncs(template.FuncMap{
+		"Synopsis": gnuSynopsis,
+		"FlagHint": gnuFlagHint,
+	})
+
+	text := strings.TrimSpace(`
+{{- $cmd := .Cmd -}}
+{{Msg $cmd "usage"}} {{Synopsis .}}
+{{- with $cmd.Description}}
+{{.}}
+{{- end}}
+{{- if $cmd.Deprecated}}
+{{Msg $cmd "deprecated"}}{{with $cmd.Deprecated.Text $cmd.Catalog}}: {{.}}{{end}}
+{{- end}}
+{{- with .Operands}}
+
+{{Msg $cmd "arguments"}}
+{{- range .}}
+  {{Row 28 (StringsToUpper .Name) .Description}}
+{{- end}}
+{{- end}}
+{{- with .Flags}}
+
+{{Msg $cmd "options"}}
+{{- range .}}
+  {{Row 28 (FlagHint $cmd .) (FlagDescription $cmd .)}}
+{{- end}}
+{{- end}}
+{{- range $parent := .Parents}}
+{{- with UnhiddenFlags $parent.FlagSet.Flags}}
+
+{{Msg $cmd "options-for" $parent.FlagSet.Name}}
+{{- range .}}
+  {{Row 28 (FlagHint $parent .) (FlagDescription $cmd .)}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- range .SubCmdCats}}
+
+{{if .Name}}{{CategoryName $cmd .Name}}:{{with .Desc}} {{.}}{{end}}{{else}}{{Msg $cmd "commands"}}{{end}}
+{{- range .Cmds}}
+  {{Row 28 .FlagSet.Name .Description}}
+{{- end}}
+{{- end}}
+{{- with .Aliases}}
+
+{{Msg $cmd "aliases-for" $cmd.FlagSet.Name}} {{StringsJoin . ", "}}
+{{- end}}
+{{- with $cmd.Examples}}
+
+{{Msg $cmd "examples"}}
+{{- range .}}
+  {{.Line}}{{with .Description}}
+      {{.}}{{end}}
+{{- end}}
+{{- end}}
+{{- template "footer" .}}
+{{- define "footer"}}{{end}}
+`) + "\n"
+
+	return &Tmpl{Text: text, FMap: fMap, Data: NewUsageView(c)}
+}
+
+// NewDocoptUsageTmpl returns a template configuration that follows the layout
+// used by docopt (i.e. one usage pattern per line, and an options section with
+// "[default: ...]" hints). Flags of parent commands are not listed.
+func NewDocoptUsageTmpl(c *Clic) *Tmpl {
+	fMap := styleFuncs(template.FuncMap{
+		"Patterns":          docoptPatterns,
+		"FlagHint":          docoptFlagHint,
+		"DocoptDescription": docoptDescription,
+	})
+
+	text := strings.TrimSpace(`
+{{- $cmd := .Cmd -}}
+{{- with $cmd.Description}}{{.}}
+
+{{end -}}
+{{- if $cmd.Deprecated}}{{Msg $cmd "deprecated"}}{{with $cmd.Deprecated.Text $cmd.Catalog}}: {{.}}{{end}}
+
+{{end -}}
+{{Msg $cmd "usage"}}
+{{- range Patterns .}}
+  {{.}}
+{{- end}}
+{{- with .Flags}}
+
+{{Msg $cmd "options"}}
+{{- range .}}
+  {{Row 24 (FlagHint $cmd .) (DocoptDescription .)}}
+{{- end}}
+{{- end}}
+{{- range .SubCmdCats}}
+
+{{if .Name}}{{CategoryName $cmd .Name}}:{{with .Desc}} {{.}}{{end}}{{else}}{{Msg $cmd "commands"}}{{end}}
+{{- range .Cmds}}
+  {{Row 24 .FlagSet.Name .Description}}
+{{- end}}
+{{- end}}
+{{- with $cmd.Examples}}
+
+{{Msg $cmd "examples"}}
+{{- range .}}
+  {{.Line}}{{with .Description}}
+      {{.}}{{end}}
+{{- end}}
+{{- end}}
+{{- template "footer" .}}
+{{- define "footer"}}{{end}}
+`) + "\n"
+
+	return &Tmpl{Text: text, FMap: fMap, Data: NewUsageView(c)}
+}
+
+// styleFuncs returns the usage funcmap with the provided style-specific funcs
+// added.
+func styleFuncs(style template.FuncMap) template.FuncMap {
+	fMap := UsageFuncs()
+	maps.Copy(fMap, style)
+	return fMap
+}
+
+// flagValueHint returns the placeholder for the value of the flag, or an empty
+// string if the flag does not take a value.
+func flagValueHint(c *Clic, flag *flagset.Flag) string {
+	if !takesValue(c, flag) {
+		return ""
+	}
+	name, _, _ := strings.Cut(flag.TypeName, "(")
+	return strings.ToUpper(name)
+}
+
+// flagDefault returns the default text of the flag unless it is empty or the
+// zero value of a bool flag.
+func flagDefault(flag *flagset.Flag) string {
+	if flag.TypeName == "bool" && flag.DefaultText == "false" {
+		return ""
+	}
+	return flag.DefaultText
+}
+
+func docoptDescription(flag *flagset.Flag) string {
+	desc := flag.Description()
+	if def := flagDefault(flag); def != "" {
+		desc = strings.TrimSpace(desc + " [default: " + def + "]")
+	}
+	return desc
+}
+
+// flagNames returns the prefixed short and long names of the flag.
+func flagNames(flag *flagset.Flag) (shorts, longs []string) {
+	for _, s := range flag.Shorts() {
+		shorts = append(shorts, "-"+s)
+	}
+	for _, l := range flag.Longs() {
+		longs = append(longs, "--"+l)
+	}
+	return shorts, longs
+}
+
+func compactFlagHint(c *Clic, flag *flagset.Flag) string {
+	shorts, longs := flagNames(flag)
+	out := strings.Join(append(shorts, longs...), ", ")
+
+	if hint := flagValueHint(c, flag); hint != "" {
+		sep := "="
+		if len(longs) == 0 {
+			sep = " "
+		}
+		out += sep + hint
+	}
+	return out
+}
+
+// gnuFlagHint aligns long names of flags without short names with those that
+// follow a short name.
+func gnuFlagHint(c *Clic, flag *flagset.Flag) string {
+	if len(flag.Shorts()) == 0 {
+		return "    " + compactFlagHint(c, flag)
+	}
+	return compactFlagHint(c, flag)
+}
+
+func docoptFlagHint(c *Clic, flag *flagset.Flag) string {
+	shorts, longs := flagNames(flag)
+	hint := flagValueHint(c, flag)
+
+	var names []string
+	for _, s := range shorts {
+		if hint != "" {
+			s += " " + hint
+		}
+		names = append(names, s)
+	}
+	for _, l := range longs {
+		if hint != "" {
+			l += "=" + hint
+		}
+		names = append(names, l)
+	}
+	return strings.Join(names, ", ")
+}
+
+// cmdNames returns the names of the commands joined by spaces.
+func cmdNames(cmds []*Clic) string {
+	names := make([]string, 0, len(cmds))
+	for _, cmd := range cmds {
+		names = append(names, cmd.FlagSet.Name())
+	}
+	return strings.Join(names, " ")
+}
+
+// cmdNamesHint returns the names of the commands joined by spaces. Each name is
+// followed by hint if the command has visible flags, since flags must follow
+// the name of the command that defines them.
+func cmdNamesHint(cmds []*Clic, hint string) string {
+	names := make([]string, 0, len(cmds))
+	for _, cmd := range cmds {
+		name := cmd.FlagSet.Name()
+		if len(unhiddenFlags(cmd.FlagSet.Flags())) > 0 {
+			name += " " + hint
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, " ")
+}
+
+func compactSynopsis(d *UsageView) string {
+	out := cmdNamesHint(d.Path(), "[FLAGS]")
+	out += subsAndOperandsHint(d.Cmd)
+	if d.Cmd.Passthrough {
+		out += " [-- ARGS...]"
+	}
+	return out
+}
+
+func gnuSynopsis(d *UsageView) string {
+	out := cmdNamesHint(d.Path(), "[OPTION]...")
+
+	switch {
+	case len(d.SubCmdCats()) > 0 && d.Cmd.SubRequired:
+		out += " COMMAND [ARG]..."
+	case len(d.SubCmdCats()) > 0:
+		out += " [COMMAND [ARG]...]"
+	default:
+		for _, op := range d.Operands() {
+			if op.IsRequired() {
+				out += " " + strings.ToUpper(op.Name())
+				continue
+			}
+			out += " [" + strings.ToUpper(op.Name()) + "]"
+		}
+	}
+
+	if d.Cmd.Passthrough {
+		out += " [-- ARG...]"
+	}
+	return out
+}
+
+// docoptPatterns returns a usage pattern for each visible subcommand, and for
+// the command itself unless a subcommand is required.
+func docoptPatterns(d *UsageView) []string {
+	base := cmdNamesHint(d.Path(), "[options]")
+
+	var out []string
+	cats := d.SubCmdCats()
+
+	for _, cat := range cats {
+		for _, sub := range cat.Cmds {
+			line := base + " " + sub.FlagSet.Name()
+			if len(unhiddenFlags(sub.FlagSet.Flags())) > 0 {
+				line += " [options]"
+			}
+			line += docoptOperands(sub.OperandSet.Operands())
+			if len(NewUsageView(sub).SubCmdCats()) > 0 {
+				line += " <command> [<args>...]"
+			}
+			out = append(out, line)
+		}
+	}
+
+	if len(cats) == 0 || !d.Cmd.SubRequired {
+		line := base + docoptOperands(d.Operands())
+		if d.Cmd.Passthrough {
+			line += " [-- <args>...]"
+		}
+		out = append(out, line)
+	}
+
+	return out
+}
+
+func docoptOperands(ops []*operandset.Operand) string {
+	var out string
+	for _, op := range ops {
+		if op.IsRequired() {
+			out += " <" + op.Name() + ">"
+			continue
+		}
+		out += " [<" + op.Name() + ">]"
+	}
+	return out
+}
@@ -0,0 +1,131 @@
+package clic
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func newStyleClic() (*Clic, *Clic) {
+	h := func(context.Context) error { return nil }
+	var (
+		verbose    bool
+		num        = 3
+		label      string
+		name, note string
+	)
+
+	add := NewFromFunc(h, "add")
+	add.Description = "Add a thing"
+	add.Flag(&label, "label|l", "label to apply")
+	add.Operand(&name, true, "name", "thing name")
+	add.Operand(&note, false, "note", "")
+
+	rm := NewFromFunc(h, "rm")
+	rm.Description = "Remove a thing"
+
+	root := NewFromFunc(h, "myapp", add, rm)
+	root.Description = "Manage things"
+	root.SubRequired = true
+	root.Flag(&verbose, "verbose|v", "verbose output")
+	root.Flag(&num, "num", "count")
+
+	return root, add
+}
+
+func TestClicSetUsageStyle(t *testing.T) {
+	tt := []struct {
+		name  string
+		style func(*Clic) *Tmpl
+		root  string
+		leaf  string
+	}{
+		{
+			"compact", NewCompactUsageTmpl,
+			`
+Usage: myapp [FLAGS] {add|rm}
+  Manage things
+  -v, --verbose        verbose output
+  --num=INT            count
+  add                  Add a thing
+  rm                   Remove a thing
+`,
+			`
+Usage: myapp [FLAGS] add [FLAGS] <name> [note]
+  Add a thing
+  -l, --label=STRING   label to apply
+`,
+		},
+		{
+			"gnu", NewGNUUsageTmpl,
+			`
+Usage: myapp [OPTION]... COMMAND [ARG]...
+Manage things
+
+Options:
+  -v, --verbose                verbose output
+      --num=INT                count (default: 3)
+
+Commands:
+  add                          Add a thing
+  rm                           Remove a thing
+`,
+			`
+Usage: myapp [OPTION]... add [OPTION]... NAME [NOTE]
+Add a thing
+
+Arguments:
+  NAME                         thing name
+  NOTE
+
+Options:
+  -l, --label=STRING           label to apply
+
+Options for myapp:
+  -v, --verbose                verbose output
+      --num=INT                count (default: 3)
+`,
+		},
+		{
+			"docopt", NewDocoptUsageTmpl,
+			`
+Manage things
+
+Usage:
+  myapp [options] add [options] <name> [<note>]
+  myapp [options] rm
+
+Options:
+  -v, --verbose            verbose output
+  --num=INT                count [default: 3]
+
+Commands:
+  add                      Add a thing
+  rm                       Remove a thing
+`,
+			`
+Add a thing
+
+Usage:
+  myapp [options] add [options] <name> [<note>]
+
+Options:
+  -l STRING, --label=STRING label to apply
+`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			root, leaf := newStyleClic()
+			root.SetUsageStyle(tc.style)
+
+			if got, want := root.Usage(), strings.TrimPrefix(tc.root, "\n"); got != want {
+				t.Errorf("root: got:\n%s\nwant:\n%s", got, want)
+			}
+			if got, want := leaf.Usage(), strings.TrimPrefix(tc.leaf, "\n"); got != want {
+				t.Errorf("leaf: got:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
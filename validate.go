@@ -0,0 +1,148 @@
+package clic
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Validate reports structural mistakes in the command tree starting at the Clic
+// instance. All problems found are returned (joined using [errors.Join]), and
+// each can be detected using [errors.Is] with the related Err value:
+//
+//   - [ErrNameConflict]: sibling subcommands share a name or alias, or a
+//     subcommand shares a name with its own alias
+//   - [ErrAliasShadowsCommand]: a user-defined alias shares a subcommand name
+//   - [ErrAliasRecursive], [ErrAliasUnresolved]: a user-defined alias expands
+//     recursively, or does not expand to a subcommand (or plugin) name
+//   - [ErrMultipleParents]: a Clic instance is attached under more than one
+//     parent (or more than once)
+//   - [ErrOperandOrder]: a required operand follows an optional operand
+//   - [ErrFlagConflict]: flags of a command share a name, or a flag of a
+//     subcommand shares a name with a flag of a parent command
+//   - [ErrCategoryUnknown]: a SubCmdCatsSort entry matches no subcommand
+//
+// Validate is intended to be called during startup or in tests. Lazy
+// placeholders (see [NewLazy]) are built.
+func (c *Clic) Validate() error {
+	var errs []error
+	seen := make(map[*Clic]bool)
+	c.validate(&errs, seen, c.FlagSet.Name())
+	return errors.Join(errs...)
+}
+
+func (c *Clic) validate(errs *[]error, seen map[*Clic]bool, path string) {
+	c.materialize()
+
+	add := func(err error, format string, args ...any) {
+		*errs = append(*errs, fmt.Errorf("%s: %w: %s", path, err, fmt.Sprintf(format, args...)))
+	}
+
+	names := make(map[string]string) // name or alias => owning subcommand name
+	attached := make(map[*Clic]bool)
+	for _, sub := range c.subs {
+		subName := sub.FlagSet.Name()
+
+		if attached[sub] {
+			add(ErrMultipleParents, "subcommand %q attached more than once", subName)
+			continue
+		}
+		attached[sub] = true
+
+		for _, name := range append([]string{subName}, sub.Aliases...) {
+			if owner, ok := names[name]; ok {
+				add(ErrNameConflict, "%q used by %q and %q", name, owner, subName)
+				continue
+			}
+			names[name] = subName
+		}
+
+		if _, ok := c.argAlias[subName]; ok {
+			add(ErrAliasShadowsCommand, "user-defined alias %q", subName)
+		}
+
+		if sub.parent != c {
+			add(ErrMultipleParents, "subcommand %q", subName)
+		}
+	}
+
+	aliases := make([]string, 0, len(c.argAlias))
+	for name := range c.argAlias {
+		aliases = append(aliases, name)
+	}
+	slices.Sort(aliases)
+
+	for _, name := range aliases {
+		if c.lookupSub(name) != nil {
+			continue // reported as shadowing
+		}
+		if _, err := c.expandAlias(name); err != nil {
+			*errs = append(*errs, fmt.Errorf("%s: %w", path, err))
+		}
+	}
+
+	c.validateFlags(add)
+	c.validateOperands(add)
+
+	for _, entry := range c.SubCmdCatsSort {
+		cat, _, _ := strings.Cut(entry, "|")
+		if !c.hasSubCategory(cat) {
+			add(ErrCategoryUnknown, "%q", cat)
+		}
+	}
+
+	for _, sub := range c.subs {
+		if seen[sub] {
+			continue
+		}
+		seen[sub] = true
+		sub.validate(errs, seen, path+" "+sub.FlagSet.Name())
+	}
+}
+
+func (c *Clic) validateFlags(add func(error, string, ...any)) {
+	names := make(map[string]bool)
+	for _, flag := range c.FlagSet.Flags() {
+		for _, name := range append(append([]string{}, flag.Longs()...), flag.Shorts()...) {
+			if names[name] {
+				add(ErrFlagConflict, "%q", name)
+			}
+			names[name] = true
+
+			for cmd := c.parent; cmd != nil; cmd = cmd.parent {
+				if cmd.FlagSet.Lookup(name) != nil {
+					add(ErrFlagConflict, "%q shadows flag of %q", name, cmd.FlagSet.Name())
+					break
+				}
+			}
+		}
+	}
+}
+
+func (c *Clic) validateOperands(add func(error, string, ...any)) {
+	var optional string
+	for _, op := range c.OperandSet.Operands() {
+		if !op.IsRequired() {
+			if optional == "" {
+				optional = op.Name()
+			}
+			continue
+		}
+		if optional != "" {
+			add(ErrOperandOrder, "required %q follows optional %q", op.Name(), optional)
+		}
+	}
+}
+
+func (c *Clic) hasSubCategory(cat string) bool {
+	if cat == PluginsCategory && c.Plugins != nil {
+		return true
+	}
+	for _, sub := range c.subs {
+		if sub.Category == cat {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package clic
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestClicValidate(t *testing.T) {
+	var a, b, c string
+
+	shared := New(nil, "shared")
+
+	one := New(nil, "one|uno")
+	one.Category = "Main"
+	one.Flag(&a, "name|n", "")
+	one.Flag(&b, "name", "")
+
+	two := New(nil, "two|uno", shared)
+	two.Operand(&a, false, "first", "")
+	two.Operand(&b, true, "second", "")
+	two.Operand(&c, true, "third", "")
+
+	two.Flag(&c, "v", "")
+
+	root := New(nil, "myapp", one, two)
+	root.Flag(&c, "verbose|v", "")
+	root.SubCmdCatsSort = []string{"Main|Main commands", "Other"}
+
+	New(nil, "another", shared)
+
+	if err := root.DefineAlias("x", "one"); err != nil {
+		t.Fatal(err)
+	}
+	root.argAlias["one"] = []string{"two"}
+	root.argAlias["y"] = []string{"nope"}
+
+	err := root.Validate()
+
+	wants := []struct {
+		cause error
+		text  string
+	}{
+		{ErrAliasShadowsCommand, `myapp: alias shadows command: user-defined alias "one"`},
+		{ErrNameConflict, `myapp: name conflict: "uno" used by "one" and "two"`},
+		{ErrAliasUnresolved, `myapp: alias unresolved: "y" expands to "nope"`},
+		{ErrCategoryUnknown, `myapp: category unknown: "Other"`},
+		{ErrFlagConflict, `myapp one: flag conflict: "name"`},
+		{ErrMultipleParents, `myapp two: multiple parents: subcommand "shared"`},
+		{ErrFlagConflict, `myapp two: flag conflict: "v" shadows flag of "myapp"`},
+		{ErrOperandOrder, `myapp two: operand order: required "second" follows optional "first"`},
+		{ErrOperandOrder, `myapp two: operand order: required "third" follows optional "first"`},
+	}
+
+	var texts []string
+	for _, want := range wants {
+		if !errors.Is(err, want.cause) {
+			t.Errorf("missing cause: %v", want.cause)
+		}
+		texts = append(texts, want.text)
+	}
+
+	if got, want := err.Error(), strings.Join(texts, "\n"); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	twice := New(nil, "twice")
+	err = New(nil, "myapp", twice, twice).Validate()
+	if !errors.Is(err, ErrMultipleParents) || errors.Is(err, ErrNameConflict) {
+		t.Fatalf("attached twice: got: %v, want: %v", err, ErrMultipleParents)
+	}
+
+	if err := New(nil, "myapp", New(nil, "sub")).Validate(); err != nil {
+		t.Fatalf("valid tree: got: %v, want: nil", err)
+	}
+}